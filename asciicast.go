@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// asciicastHeader は asciicast v2 形式の1行目に書くヘッダーです。
+type asciicastHeader struct {
+	Version int               `json:"version"`
+	Width   int               `json:"width"`
+	Height  int               `json:"height"`
+	Env     map[string]string `json:"env"`
+}
+
+// writeAsciicast はフレームを asciinema で再生できる asciicast v2 形式で書き出します。
+// 各フレームは前のフレームからの差分だけを出力イベントとして記録します。
+func writeAsciicast(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to export")
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	header := asciicastHeader{
+		Version: 2,
+		Width:   frames[0].Canvas.Width(),
+		Height:  frames[0].Canvas.Height(),
+		Env:     map[string]string{"TERM": "xterm-256color"},
+	}
+	if err := encoder.Encode(header); err != nil {
+		return fmt.Errorf("error writing asciicast header: %w", err)
+	}
+
+	renderer := splash.NewDiffRenderer()
+	for i, frame := range frames {
+		data := renderer.Render(frame.Canvas)
+		if data == "" {
+			continue
+		}
+		if i == 0 {
+			// 再生中にカーソルが点滅しないよう隠しておく
+			data = "\x1b[?25l" + data
+		}
+		event := []any{frame.Time.Seconds(), "o", data}
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("error writing asciicast event: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// keyMap は再生中に使えるキーの一覧です。
+type keyMap struct {
+	Pause       key.Binding
+	StepForward key.Binding
+	StepBack    key.Binding
+	NextPhase   key.Binding
+	PrevPhase   key.Binding
+	Faster      key.Binding
+	Slower      key.Binding
+	Reverse     key.Binding
+	Status      key.Binding
+	Quit        key.Binding
+}
+
+var keys = keyMap{
+	Pause: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "pause"),
+	),
+	StepForward: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→", "step"),
+	),
+	StepBack: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←", "step back"),
+	),
+	NextPhase: key.NewBinding(
+		key.WithKeys("]", "n"),
+		key.WithHelp("]", "next phase"),
+	),
+	PrevPhase: key.NewBinding(
+		key.WithKeys("[", "p"),
+		key.WithHelp("[", "prev phase"),
+	),
+	Faster: key.NewBinding(
+		key.WithKeys("+", "=", "up"),
+		key.WithHelp("+", "faster"),
+	),
+	Slower: key.NewBinding(
+		key.WithKeys("-", "down"),
+		key.WithHelp("-", "slower"),
+	),
+	Reverse: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "reverse"),
+	),
+	Status: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "status"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// playbackSpeeds は選べる再生速度の倍率です。遅い順に並べます。
+var playbackSpeeds = []float64{0.25, 0.5, 1, 2, 4}
+
+// changeSpeed は playbackSpeeds の中で current から step 段階ずらした速度を返します。端の速度より先には進みません。
+func changeSpeed(current float64, step int) float64 {
+	index := 0
+	for i, speed := range playbackSpeeds {
+		if speed <= current {
+			index = i
+		}
+	}
+	return playbackSpeeds[min(max(index+step, 0), len(playbackSpeeds)-1)]
+}
+
+// statusLine は再生中のフェーズと速度、使えるキーを1行にまとめます。
+func statusLine(m *splash.SlideModel) string {
+	state := "playing"
+	if m.Paused {
+		state = "paused"
+	}
+	if m.Reverse {
+		state += " (reverse)"
+	}
+	b := strings.Builder{}
+	fmt.Fprintf(&b, " %s %3.0f%%  %gx  %s ", m.CurrentPhase().Name, 100*m.Ratio, m.Speed, state)
+	for _, binding := range []key.Binding{keys.Pause, keys.StepBack, keys.StepForward, keys.PrevPhase, keys.NextPhase, keys.Slower, keys.Faster, keys.Reverse, keys.Status, keys.Quit} {
+		help := binding.Help()
+		fmt.Fprintf(&b, " %s %s ", help.Key, help.Desc)
+	}
+	return b.String()
+}
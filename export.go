@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// Frame は書き出す1フレーム分の画面と、そのフレームを表示し始める時刻です。
+type Frame struct {
+	Time   time.Duration
+	Canvas *splash.Canvas
+}
+
+// exporter は書き出し形式ごとの既定の拡張子と書き出し処理です。
+type exporter struct {
+	ext   string
+	write func(w io.Writer, frames []Frame) error
+}
+
+// exporters は export サブコマンドで選べる書き出し形式の一覧です。
+var exporters = map[string]exporter{
+	"asciicast": {ext: ".cast", write: writeAsciicast},
+	"gif":       {ext: ".gif", write: writeGIF},
+	"html":      {ext: ".html", write: writeHTML},
+	"svg":       {ext: ".svg", write: writeSVG},
+}
+
+// runExport は端末を使わずにアニメーションを1フレームずつ進め、ファイルに書き出します。
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "asciicast", "output format: "+strings.Join(exporterNames(), ", "))
+	output := flags.String("o", "", "output file (default intro.<ext>, - for stdout)")
+	sceneFlags := addSceneFlags(flags)
+	width := flags.Int("width", splash.DesignWidth, "canvas width in cells")
+	height := flags.Int("height", splash.DesignHeight, "canvas height in cells")
+	loops := flags.Int("loops", 1, "number of times to play the timeline")
+	colorName := flags.String("color", "truecolor", "color profile: truecolor, 256, 16 or none")
+	dither := flags.Bool("dither", false, "use ordered dithering when the color profile has fewer colors than truecolor")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	exp, ok := exporters[*format]
+	if !ok {
+		return fmt.Errorf("unknown export format %q (want one of %s)", *format, strings.Join(exporterNames(), ", "))
+	}
+	if *width <= 0 || *height <= 0 {
+		return fmt.Errorf("-width and -height must be positive, got %dx%d", *width, *height)
+	}
+	if *loops <= 0 {
+		return fmt.Errorf("-loops must be positive, got %d", *loops)
+	}
+	scene, err := sceneFlags.load()
+	if err != nil {
+		return err
+	}
+	profile, err := splash.ParseColorProfile(*colorName, nil)
+	if err != nil {
+		return err
+	}
+
+	m := splash.NewSlideModel(scene)
+	m.Resize(*width, *height)
+	frames := recordFrames(m, *loops)
+	colors := splash.NewColorMapper(profile, *dither)
+	for i := range frames {
+		frames[i].Canvas = colors.Convert(frames[i].Canvas)
+	}
+
+	path := *output
+	if path == "" {
+		path = "intro" + exp.ext
+	}
+	if path == "-" {
+		return exp.write(os.Stdout, frames)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	if err := exp.write(file, frames); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// recordFrames は m をタイムライン loops 周分進めながら、各フレームの画面を複製して集めます。
+// 実際の時刻ではなく FakeClock をフレームの間隔で進めるので、待たずに書き出せて何度書き出しても同じ結果になります。
+func recordFrames(m *splash.SlideModel, loops int) []Frame {
+	start := time.Time{}
+	clock := splash.NewFakeClock(start)
+	m.SetClock(clock)
+
+	length := m.Timeline.Length() * time.Duration(loops)
+	count := int((length + splash.FrameInterval - 1) / splash.FrameInterval)
+	frames := make([]Frame, 0, count)
+	for i := 0; i < count; i++ {
+		frames = append(frames, Frame{
+			Time:   clock.Now().Sub(start),
+			Canvas: m.Draw().Clone(),
+		})
+		clock.Add(splash.FrameInterval)
+		m.Update()
+	}
+	return frames
+}
+
+func exporterNames() []string {
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
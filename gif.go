@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// defaultForeground と defaultBackground は色の付いていないマスを画像にするときの色です。
+var defaultForeground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+var defaultBackground = color.RGBA{0x00, 0x00, 0x00, 0xff}
+
+// writeGIF はフレームを組み込みのビットマップフォントで画像にし、アニメーション GIF として書き出します。
+// 2フレーム目以降は前のフレームから変わったマスを囲む範囲だけを書き出します。
+func writeGIF(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to export")
+	}
+	first := frames[0].Canvas
+	if first.Width() == 0 || first.Height() == 0 {
+		return fmt.Errorf("cannot export an empty %dx%d canvas", first.Width(), first.Height())
+	}
+	anim := &gif.GIF{
+		Config: image.Config{
+			Width:  first.Width() * cellPixelWidth,
+			Height: first.Height() * cellPixelHeight,
+		},
+	}
+
+	var prev *splash.Canvas
+	var prevTime int
+	for i, frame := range frames {
+		area := first.Bounds()
+		if prev != nil {
+			area = changedArea(prev, frame.Canvas)
+		}
+		// GIF の表示時間は1/100秒単位なので、丸め誤差が積み重ならないよう時刻から求める
+		end := centiseconds(frame.Time + splash.FrameInterval)
+		if i+1 < len(frames) {
+			end = centiseconds(frames[i+1].Time)
+		}
+		if area.Width == 0 || area.Height == 0 {
+			// 変化がなければ前のフレームを長く表示する
+			anim.Delay[len(anim.Delay)-1] += end - prevTime
+			prevTime = end
+			continue
+		}
+		anim.Image = append(anim.Image, rasterize(frame.Canvas, area))
+		anim.Delay = append(anim.Delay, end-centiseconds(frame.Time))
+		prev = frame.Canvas
+		prevTime = end
+	}
+
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("error encoding GIF: %w", err)
+	}
+	return nil
+}
+
+// centiseconds は時刻を1/100秒単位に丸めます。
+func centiseconds(d time.Duration) int {
+	return int((d.Milliseconds() + 5) / 10)
+}
+
+// changedArea は prev と c で内容が異なるマスを全て含む最小の矩形を返します。
+func changedArea(prev, c *splash.Canvas) splash.Rect {
+	x0, y0, x1, y1 := c.Width(), c.Height(), -1, -1
+	for y := 0; y < c.Height(); y++ {
+		for x := 0; x < c.Width(); x++ {
+			if prev.At(x, y) == c.At(x, y) {
+				continue
+			}
+			x0, y0 = min(x0, x), min(y0, y)
+			x1, y1 = max(x1, x), max(y1, y)
+		}
+	}
+	if x1 < 0 {
+		return splash.Rect{}
+	}
+	return splash.Rect{X: x0, Y: y0, Width: x1 - x0 + 1, Height: y1 - y0 + 1}
+}
+
+// rasterize はキャンバスの area の範囲を画素に変換します。
+// 使われている色が256色を超える場合は、よく使われる256色を選び、残りはその中で最も近い色に置き換えます。
+func rasterize(c *splash.Canvas, area splash.Rect) *image.Paletted {
+	counts := map[color.RGBA]int{}
+	for y := area.Y; y < area.Y+area.Height; y++ {
+		for x := area.X; x < area.X+area.Width; x++ {
+			cell := c.At(x, y)
+			counts[cellRGBA(cell.FG, defaultForeground)]++
+			counts[cellRGBA(cell.BG, defaultBackground)]++
+		}
+	}
+	used := make([]color.RGBA, 0, len(counts))
+	for rgba := range counts {
+		used = append(used, rgba)
+	}
+	sort.Slice(used, func(i, j int) bool {
+		if counts[used[i]] != counts[used[j]] {
+			return counts[used[i]] > counts[used[j]]
+		}
+		return rgbaKey(used[i]) < rgbaKey(used[j])
+	})
+	colors := color.Palette{}
+	index := map[color.RGBA]uint8{}
+	for _, rgba := range used {
+		if len(colors) == 256 {
+			index[rgba] = uint8(colors.Index(rgba))
+			continue
+		}
+		index[rgba] = uint8(len(colors))
+		colors = append(colors, rgba)
+	}
+
+	bounds := image.Rect(area.X*cellPixelWidth, area.Y*cellPixelHeight, (area.X+area.Width)*cellPixelWidth, (area.Y+area.Height)*cellPixelHeight)
+	img := image.NewPaletted(bounds, colors)
+	for y := area.Y; y < area.Y+area.Height; y++ {
+		for x := area.X; x < area.X+area.Width; x++ {
+			cell := c.At(x, y)
+			fg := index[cellRGBA(cell.FG, defaultForeground)]
+			bg := index[cellRGBA(cell.BG, defaultBackground)]
+			mask := glyphMaskOf(cell.Rune)
+			for py := 0; py < cellPixelHeight; py++ {
+				for px := 0; px < cellPixelWidth; px++ {
+					i := bg
+					if mask[py][px] {
+						i = fg
+					}
+					img.SetColorIndex(x*cellPixelWidth+px, y*cellPixelHeight+py, i)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// rgbaKey は色を並べ替えるときの順序を決める値です。
+func rgbaKey(c color.RGBA) uint32 {
+	return uint32(c.R)<<16 | uint32(c.G)<<8 | uint32(c.B)
+}
+
+// cellRGBA はマスの色を画素の色に変換します。色がなければ fallback を返します。
+func cellRGBA(c termenv.Color, fallback color.RGBA) color.RGBA {
+	if c == nil {
+		return fallback
+	}
+	if _, ok := c.(termenv.NoColor); ok {
+		return fallback
+	}
+	r, g, b := termenv.ConvertToRGB(c).RGB255()
+	return color.RGBA{r, g, b, 0xff}
+}
+
+// cellHex はマスの色を #rrggbb 形式で返します。色がなければ fallback を使います。
+func cellHex(c termenv.Color, fallback color.RGBA) string {
+	rgba := cellRGBA(c, fallback)
+	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// htmlPlayerHead は HTML プレイヤーのスタイルと操作部分です。%s には色のクラス定義が入ります。
+const htmlPlayerHead = `<!DOCTYPE html>
+<html lang="ja">
+<head>
+<meta charset="utf-8">
+<title>charm-demo</title>
+<style>
+body{margin:0;background:#111;color:#eee;font-family:sans-serif}
+.frames pre{display:none;margin:0;font-family:monospace;line-height:1;font-size:14px}
+.frames pre.current{display:block}
+.controls{display:flex;gap:8px;align-items:center;padding:8px}
+.controls input[type=range]{flex:1}
+%s</style>
+</head>
+<body>
+<div class="frames">
+`
+
+// htmlPlayerTail はフレームを時刻に合わせて切り替えるスクリプトです。%s にはフレームの表示時刻(ミリ秒)の配列が、%d にはループ全体の長さが入ります。
+const htmlPlayerTail = `</div>
+<div class="controls">
+<button id="play">Pause</button>
+<input id="scrub" type="range" min="0" value="0">
+<span id="time"></span>
+</div>
+<script>
+(function () {
+  var times = [%s], total = %d;
+  var frames = document.querySelectorAll(".frames pre");
+  var play = document.getElementById("play"), scrub = document.getElementById("scrub"), label = document.getElementById("time");
+  var current = 0, playing = true, origin = performance.now();
+  scrub.max = frames.length - 1;
+  function show(i) {
+    frames[current].classList.remove("current");
+    current = i;
+    frames[current].classList.add("current");
+    scrub.value = i;
+    label.textContent = (times[i] / 1000).toFixed(3) + "s";
+  }
+  function frameAt(t) {
+    var i = 0;
+    while (i + 1 < times.length && times[i + 1] <= t) i++;
+    return i;
+  }
+  function tick(now) {
+    if (playing) {
+      var i = frameAt((now - origin) %% total);
+      if (i !== current) show(i);
+    }
+    requestAnimationFrame(tick);
+  }
+  play.onclick = function () {
+    playing = !playing;
+    play.textContent = playing ? "Pause" : "Play";
+    origin = performance.now() - times[current];
+  };
+  scrub.oninput = function () {
+    show(Number(scrub.value));
+    origin = performance.now() - times[current];
+  };
+  show(0);
+  requestAnimationFrame(tick);
+})();
+</script>
+</body>
+</html>
+`
+
+// writeHTML はフレームを <pre> に並べ、再生・一時停止・シークのできる単独の HTML として書き出します。
+// 色は組み合わせごとにクラスにまとめ、同じクラスの続くマスはひとつの <span> にします。
+func writeHTML(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to export")
+	}
+
+	classes := map[string]string{}
+	var styles strings.Builder
+	classOf := func(cell splash.Cell) string {
+		style := fmt.Sprintf("color:%s;background:%s", cellHex(cell.FG, defaultForeground), cellHex(cell.BG, defaultBackground))
+		if class, ok := classes[style]; ok {
+			return class
+		}
+		class := fmt.Sprintf("c%d", len(classes))
+		classes[style] = class
+		fmt.Fprintf(&styles, ".%s{%s}\n", class, style)
+		return class
+	}
+
+	var body strings.Builder
+	times := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		times = append(times, fmt.Sprint(frame.Time.Milliseconds()))
+		body.WriteString("<pre>")
+		for y := 0; y < frame.Canvas.Height(); y++ {
+			if y > 0 {
+				body.WriteString("\n")
+			}
+			row := frame.Canvas.Row(y)
+			for x := 0; x < len(row); {
+				class := classOf(row[x])
+				end := x + 1
+				for end < len(row) && classOf(row[end]) == class {
+					end++
+				}
+				text := strings.Builder{}
+				for _, cell := range row[x:end] {
+					text.WriteRune(cell.Rune)
+				}
+				fmt.Fprintf(&body, `<span class="%s">%s</span>`, class, html.EscapeString(text.String()))
+				x = end
+			}
+		}
+		body.WriteString("</pre>\n")
+	}
+
+	total := frames[len(frames)-1].Time + splash.FrameInterval
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, htmlPlayerHead, styles.String())
+	b.WriteString(body.String())
+	fmt.Fprintf(b, htmlPlayerTail, strings.Join(times, ","), total.Milliseconds())
+	if err := b.Flush(); err != nil {
+		return fmt.Errorf("error writing HTML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// runImportSVG は SVG ファイルの線をマス目に合わせ、leftLine.json と同じ書式の JSON に書き出します。
+func runImportSVG(args []string) error {
+	flags := flag.NewFlagSet("import-svg", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: import-svg [flags] file.svg")
+		flags.PrintDefaults()
+	}
+	output := flags.String("o", "-", "output file (- for stdout)")
+	width := flags.Int("width", splash.DesignWidth, "width of the cell grid the SVG viewBox is scaled to")
+	height := flags.Int("height", splash.DesignHeight, "height of the cell grid the SVG viewBox is scaled to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("want exactly one SVG file, got %d", flags.NArg())
+	}
+	if *width <= 0 || *height <= 0 {
+		return fmt.Errorf("-width and -height must be positive, got %dx%d", *width, *height)
+	}
+
+	lines, err := splash.LoadSVG(flags.Arg(0), *width, *height)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(lines, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error marshalling JSON: %w", err)
+	}
+	data = append(data, '\n')
+
+	if *output == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*output, data, 0o644); err != nil {
+		return fmt.Errorf("error writing output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Package golden はテストの出力を testdata に置いた期待値のファイルと比べるためのヘルパーです。
+// golden を使うパッケージのテストを `go test ./splash -update` のように実行すると、比べる代わりに期待値のファイルを書き換えます。
+// -update は golden を読み込んだパッケージにしかないので、`go test ./... -update` では他のパッケージが失敗します。
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files instead of comparing against them")
+
+// Dir は期待値のファイルを置くディレクトリです。テストを実行するパッケージからの相対パスです。
+var Dir = filepath.Join("testdata", "golden")
+
+// Path は name という名前の期待値のファイルのパスを返します。
+func Path(name string) string {
+	return filepath.Join(Dir, name+".golden")
+}
+
+// Assert は got を name の期待値と比べ、異なれば最初に食い違った行を示してテストを失敗させます。
+// -update が指定されていれば、got で期待値のファイルを書き換えます。
+func Assert(t testing.TB, name string, got []byte) {
+	t.Helper()
+	path := Path(name)
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("error creating golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("error writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading golden file (run with -update to create it): %v", err)
+	}
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < max(len(gotLines), len(wantLines)); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Fatalf("%s differs from golden file at line %d (run with -update to accept)\n got: %q\nwant: %q", path, i+1, g, w)
+		}
+	}
+	t.Fatalf("%s differs from golden file (run with -update to accept)", path)
+}
@@ -1,52 +1,254 @@
-package main
-
-import (
-	"fmt"
-	"os"
-	"time"
-
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/mattn/go-runewidth"
-)
-
-func main() {
-	runewidth.DefaultCondition.EastAsianWidth = false
-	if _, err := tea.NewProgram(model{slide: Init()}, tea.WithFPS(25)).Run(); err != nil {
-		fmt.Println("Oh no!", err)
-		os.Exit(1)
-	}
-}
-
-type tickMsg time.Time
-
-type model struct {
-	slide *SlideModel
-}
-
-func (m model) Init() tea.Cmd {
-	return tickCmd()
-}
-
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg.(type) {
-	case tea.KeyMsg:
-		return m, tea.Quit
-
-	case tickMsg:
-		m.slide = m.slide.Update()
-		return m, tickCmd()
-
-	default:
-		return m, nil
-	}
-}
-
-func (m model) View() string {
-	return m.slide.View()
-}
-
-func tickCmd() tea.Cmd {
-	return tea.Tick(44*time.Millisecond, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
-}
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/termenv"
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// commands はサブコマンドの一覧です。サブコマンドを指定しなければ端末でイントロを再生します。
+var commands = map[string]func(args []string) error{
+	"export":     runExport,
+	"import-svg": runImportSVG,
+	"snapshot":   runSnapshot,
+}
+
+func main() {
+	// Bubble Tea の描画が行の幅を測るときも、ブロック要素を1マスと数えるようにする。splash は自前の条件で測るのでこの設定に頼らない
+	runewidth.DefaultCondition.EastAsianWidth = false
+
+	run, args := runPlay, os.Args[1:]
+	if len(args) > 0 {
+		if command, ok := commands[args[0]]; ok {
+			run, args = command, args[1:]
+		}
+	}
+	if err := run(args); err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		fmt.Println("Oh no!", err)
+		os.Exit(1)
+	}
+}
+
+// runPlay は端末でイントロを再生します。
+func runPlay(args []string) error {
+	flags := flag.NewFlagSet("charm-demo", flag.ContinueOnError)
+	sceneFlags := addSceneFlags(flags)
+	rendererName := flags.String("renderer", "diff", "how frames are written to the terminal: diff or full")
+	colorName := flags.String("color", "auto", "color profile: auto, truecolor, 256, 16 or none")
+	dither := flags.Bool("dither", false, "use ordered dithering when the terminal has fewer colors than truecolor")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	scene, err := sceneFlags.load()
+	if err != nil {
+		return err
+	}
+	profile, err := splash.ParseColorProfile(*colorName, termenv.NewOutput(os.Stdout))
+	if err != nil {
+		return err
+	}
+
+	m := model{slide: splash.NewSlideModel(scene), colors: splash.NewColorMapper(profile, *dither), clock: splash.RealClock()}
+	m.slide.SetClock(m.clock)
+	switch *rendererName {
+	case "diff":
+		return runWithDiffRenderer(m)
+	case "full":
+		_, err := tea.NewProgram(m, tea.WithFPS(splash.FrameRate), tea.WithAltScreen()).Run()
+		return err
+	default:
+		return fmt.Errorf("unknown renderer %q (want diff or full)", *rendererName)
+	}
+}
+
+// sceneFlags はシーンを選ぶためのフラグで、play, export, snapshot で共通です。
+type sceneFlags struct {
+	path       *string
+	text       *string
+	font       *string
+	leftLines  *string
+	rightLines *string
+}
+
+// addSceneFlags はシーンを選ぶフラグを flags に加えます。
+// 線のファイルは環境変数 CHARM_DEMO_LEFT_LINES と CHARM_DEMO_RIGHT_LINES でも指定でき、フラグがあればそちらを使います。
+func addSceneFlags(flags *flag.FlagSet) *sceneFlags {
+	return &sceneFlags{
+		path:       flags.String("scene", "", "path to a JSON or YAML scene file"),
+		text:       flags.String("text", "", "text to draw as the logo instead of the scene's"),
+		font:       flags.String("font", "", "path to a FIGlet .flf font to draw the logo text with"),
+		leftLines:  flags.String("left-lines", os.Getenv("CHARM_DEMO_LEFT_LINES"), "JSON or SVG file with the left-hand lines (env CHARM_DEMO_LEFT_LINES)"),
+		rightLines: flags.String("right-lines", os.Getenv("CHARM_DEMO_RIGHT_LINES"), "JSON or SVG file with the right-hand lines instead of mirroring the left (env CHARM_DEMO_RIGHT_LINES)"),
+	}
+}
+
+// load はフラグが空なら既定のシーンを、そうでなければシーンファイルを読み込みます。
+// テキストやフォント、線のファイルが指定されていれば、シーンのものを置き換えます。
+func (f *sceneFlags) load() (*splash.Scene, error) {
+	scene := splash.DefaultScene()
+	if *f.path != "" {
+		var err error
+		scene, err = splash.LoadScene(*f.path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if *f.leftLines != "" {
+		lines, err := splash.ReadLines(*f.leftLines)
+		if err != nil {
+			return nil, fmt.Errorf("error loading left lines: %w", err)
+		}
+		scene.LeftLines = lines
+	}
+	if *f.rightLines != "" {
+		lines, err := splash.ReadLines(*f.rightLines)
+		if err != nil {
+			return nil, fmt.Errorf("error loading right lines: %w", err)
+		}
+		scene.RightLines = lines
+		scene.Symmetry = nil
+	}
+	scene.UpdateLines()
+
+	if *f.text == "" && *f.font == "" {
+		return scene, nil
+	}
+	if *f.text != "" {
+		scene.Text = *f.text
+	}
+	if *f.font != "" {
+		font, err := splash.LoadFIGletFont(*f.font)
+		if err != nil {
+			return nil, err
+		}
+		scene.Font = font
+	}
+	if err := scene.UpdateLogo(); err != nil {
+		return nil, fmt.Errorf("error drawing logo: %w", err)
+	}
+	return scene, nil
+}
+
+// runWithDiffRenderer は Bubble Tea の描画を止め、変化したマスだけを自前で端末に書き込みながら再生します。
+func runWithDiffRenderer(m model) error {
+	output := termenv.NewOutput(os.Stdout)
+	output.AltScreen()
+	output.HideCursor()
+	defer func() {
+		output.ShowCursor()
+		output.ExitAltScreen()
+	}()
+
+	m.out = output
+	m.renderer = splash.NewDiffRenderer()
+	_, err := tea.NewProgram(m, tea.WithoutRenderer()).Run()
+	return err
+}
+
+type tickMsg time.Time
+
+type model struct {
+	slide    *splash.SlideModel
+	colors   *splash.ColorMapper
+	out      io.Writer            // out は renderer が差分を書き込む先です。
+	renderer *splash.DiffRenderer // renderer が nil のときは View で画面全体を返します。
+	clock    splash.Clock
+	status   bool // status が真なら画面の最下行に再生状態を表示します。
+}
+
+func (m model) Init() tea.Cmd {
+	return tickCmd(m.clock)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, keys.Pause):
+			m.slide.Paused = !m.slide.Paused
+		case key.Matches(msg, keys.StepForward):
+			m.slide.Paused = true
+			m.slide.Advance(splash.FrameInterval)
+		case key.Matches(msg, keys.StepBack):
+			m.slide.Paused = true
+			m.slide.Advance(-splash.FrameInterval)
+		case key.Matches(msg, keys.NextPhase):
+			if n := len(m.slide.Timeline); n > 0 {
+				m.slide.Seek((m.slide.Phase+1)%n, 0)
+			}
+		case key.Matches(msg, keys.PrevPhase):
+			if n := len(m.slide.Timeline); n > 0 {
+				m.slide.Seek((m.slide.Phase-1+n)%n, 0)
+			}
+		case key.Matches(msg, keys.Faster):
+			m.slide.Speed = changeSpeed(m.slide.Speed, 1)
+		case key.Matches(msg, keys.Slower):
+			m.slide.Speed = changeSpeed(m.slide.Speed, -1)
+		case key.Matches(msg, keys.Reverse):
+			m.slide.Reverse = !m.slide.Reverse
+		case key.Matches(msg, keys.Status):
+			m.status = !m.status
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.slide.Resize(msg.Width, msg.Height)
+		if m.renderer != nil {
+			m.renderer.Reset()
+		}
+		return m, nil
+
+	case tickMsg:
+		m.slide = m.slide.Update()
+		if m.renderer != nil {
+			io.WriteString(m.out, m.renderer.Render(m.colors.Convert(m.frame())))
+		}
+		return m, tickCmd(m.clock)
+
+	default:
+		return m, nil
+	}
+}
+
+func (m model) View() string {
+	if m.renderer != nil {
+		return ""
+	}
+	return m.colors.Convert(m.frame()).String()
+}
+
+// frame は現在のフレームを描き、status が真なら最下行に再生状態を重ねます。
+func (m model) frame() *splash.Canvas {
+	canvas := m.slide.Draw()
+	if !m.status {
+		return canvas
+	}
+	// スライドのキャンバスは次のフレームでも使うので、複製に書き込む
+	canvas = canvas.Clone()
+	line := splash.TextCanvas(statusLine(m.slide))
+	line.FillFG(termenv.TrueColor.Color(m.slide.Palette().Text))
+	line.FillBG(termenv.TrueColor.Color(m.slide.Palette().Background))
+	canvas.Blit(line, 0, canvas.Height()-1)
+	return canvas
+}
+
+func tickCmd(clock splash.Clock) tea.Cmd {
+	return clock.Tick(splash.FrameInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
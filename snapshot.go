@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// runSnapshot は Bubble Tea を使わずに指定した瞬間の1フレームを描き、標準出力に書き出します。
+// -phase と -ratio でフェーズの中の位置を、-frame で最初からのフレーム数を、または -at で最初からの経過時間を指定します。
+func runSnapshot(args []string) error {
+	flags := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	phase := flags.String("phase", "", "name of the phase to show, e.g. Horizontal")
+	ratio := flags.Float64("ratio", 0, "position inside the phase, from 0 to 1")
+	frame := flags.Int("frame", 0, "number of frames from the start of the timeline (instead of -phase)")
+	at := flags.Duration("at", 0, "time from the start of the timeline, e.g. 2.5s (instead of -phase)")
+	format := flags.String("format", "ansi", "output format: ansi or plain")
+	sceneFlags := addSceneFlags(flags)
+	width := flags.Int("width", splash.DesignWidth, "canvas width in cells")
+	height := flags.Int("height", splash.DesignHeight, "canvas height in cells")
+	colorName := flags.String("color", "truecolor", "color profile for ansi output: truecolor, 256, 16 or none")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *width <= 0 || *height <= 0 {
+		return fmt.Errorf("-width and -height must be positive, got %dx%d", *width, *height)
+	}
+
+	scene, err := sceneFlags.load()
+	if err != nil {
+		return err
+	}
+	m := splash.NewSlideModel(scene)
+	m.Resize(*width, *height)
+
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+
+	switch {
+	case set["ratio"] && *phase == "":
+		return fmt.Errorf("-ratio can only be used together with -phase")
+	case *phase != "" && set["frame"]:
+		return fmt.Errorf("-phase and -frame cannot be used together")
+	case *phase != "" && set["at"]:
+		return fmt.Errorf("-phase and -at cannot be used together")
+	case set["frame"] && set["at"]:
+		return fmt.Errorf("-frame and -at cannot be used together")
+	case *frame < 0:
+		return fmt.Errorf("-frame must not be negative, got %d", *frame)
+	case *at < 0:
+		return fmt.Errorf("-at must not be negative, got %s", *at)
+	case *phase != "":
+		index := m.PhaseIndex(*phase)
+		if index < 0 {
+			names := make([]string, 0, len(m.Timeline))
+			for _, p := range m.Timeline {
+				names = append(names, p.Name)
+			}
+			return fmt.Errorf("unknown phase %q (want one of %s)", *phase, strings.Join(names, ", "))
+		}
+		if *ratio < 0 || *ratio > 1 {
+			return fmt.Errorf("-ratio must be between 0 and 1, got %g", *ratio)
+		}
+		m.Seek(index, *ratio)
+	case set["frame"]:
+		m.SeekFrame(*frame)
+	case set["at"]:
+		m.SeekTime(*at)
+	}
+
+	canvas := m.Draw()
+	switch *format {
+	case "plain":
+		fmt.Fprintln(os.Stdout, canvas.Text())
+	case "ansi":
+		profile, err := splash.ParseColorProfile(*colorName, nil)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, splash.NewColorMapper(profile, false).Convert(canvas).String())
+	default:
+		return fmt.Errorf("unknown snapshot format %q (want ansi or plain)", *format)
+	}
+	return nil
+}
@@ -1,15 +1,15 @@
-package main
-
-var barRight = `  ███  ███
-  ███  ███
-  ███  ███
-  ███  ███
-  ███  ███
-  ███  ███`
-
-var barLeft = `███  ███  
-███  ███  
-███  ███  
-███  ███  
-███  ███  
-███  ███  `
+package splash
+
+var barRight = `  ███  ███
+  ███  ███
+  ███  ███
+  ███  ███
+  ███  ███
+  ███  ███`
+
+var barLeft = `███  ███  
+███  ███  
+███  ███  
+███  ███  
+███  ███  
+███  ███  `
@@ -0,0 +1,280 @@
+package splash
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// Attrs は文字の装飾を表すビットフラグです。
+type Attrs uint8
+
+// Attrs の許容される値を定義します。
+const (
+	Bold Attrs = 1 << iota
+	Faint
+	Italic
+	Underline
+	Reverse
+)
+
+// Cell は画面の1マス分の内容を表す構造体です。
+// FG や BG が nil のマスは、Blit したときに描画先の色をそのまま残します。
+type Cell struct {
+	Rune  rune
+	FG    termenv.Color
+	BG    termenv.Color
+	Attrs Attrs
+}
+
+// Rect は画面上の矩形を表す構造体です。
+type Rect struct {
+	X, Y, Width, Height int
+}
+
+// Intersect は2つの矩形の重なりを返します。重ならない場合は大きさ0の矩形を返します。
+func (r Rect) Intersect(o Rect) Rect {
+	x0, y0 := max(r.X, o.X), max(r.Y, o.Y)
+	x1, y1 := min(r.X+r.Width, o.X+o.Width), min(r.Y+r.Height, o.Y+o.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return Rect{X: x0, Y: y0}
+	}
+	return Rect{X: x0, Y: y0, Width: x1 - x0, Height: y1 - y0}
+}
+
+// Contains は点が矩形の内側にあるかを返します。
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Canvas は Cell を敷き詰めた描画先です。
+// 範囲外への書き込みは無視されるので、描画する側で境界を確かめる必要はありません。
+// Sub で作ったキャンバスは元のキャンバスとマスを共有します。
+type Canvas struct {
+	cells  []Cell
+	stride int  // stride は元のキャンバスの幅です。
+	origin Rect // origin は元のキャンバス上でのこのキャンバスの位置と大きさです。
+	clip   Rect // clip は元のキャンバス上で書き込みを許す範囲です。
+}
+
+// NewCanvas は全てのマスを fill で埋めたキャンバスを作ります。
+func NewCanvas(width, height int, fill Cell) *Canvas {
+	width, height = max(width, 0), max(height, 0)
+	cells := make([]Cell, width*height)
+	for i := range cells {
+		cells[i] = fill
+	}
+	bounds := Rect{Width: width, Height: height}
+	return &Canvas{cells: cells, stride: width, origin: bounds, clip: bounds}
+}
+
+// TextCanvas は複数行の文字列から色を持たないキャンバスを作ります。
+// 短い行の右側は空白で埋めます。
+func TextCanvas(text string) *Canvas {
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line)))
+	}
+	c := NewCanvas(width, len(lines), Cell{Rune: ' '})
+	for y, line := range lines {
+		for x, r := range []rune(line) {
+			c.SetRune(x, y, r)
+		}
+	}
+	return c
+}
+
+// Width はキャンバスの幅を返します。
+func (c *Canvas) Width() int {
+	return c.origin.Width
+}
+
+// Height はキャンバスの高さを返します。
+func (c *Canvas) Height() int {
+	return c.origin.Height
+}
+
+// Bounds はキャンバス自身の座標系での範囲を返します。
+func (c *Canvas) Bounds() Rect {
+	return Rect{Width: c.origin.Width, Height: c.origin.Height}
+}
+
+// index はキャンバス上の座標に対応するマスの位置を返します。書き込めない座標では false を返します。
+func (c *Canvas) index(x, y int) (int, bool) {
+	ax, ay := c.origin.X+x, c.origin.Y+y
+	if !c.clip.Contains(ax, ay) {
+		return 0, false
+	}
+	return ay*c.stride + ax, true
+}
+
+// At は座標のマスを返します。範囲外ではゼロ値を返します。
+func (c *Canvas) At(x, y int) Cell {
+	if i, ok := c.index(x, y); ok {
+		return c.cells[i]
+	}
+	return Cell{}
+}
+
+// Set は座標のマスを置き換えます。
+func (c *Canvas) Set(x, y int, cell Cell) {
+	if i, ok := c.index(x, y); ok {
+		c.cells[i] = cell
+	}
+}
+
+// SetRune は座標のマスの文字だけを置き換えます。
+func (c *Canvas) SetRune(x, y int, r rune) {
+	if i, ok := c.index(x, y); ok {
+		c.cells[i].Rune = r
+	}
+}
+
+// SetFG は座標のマスの文字色だけを置き換えます。
+func (c *Canvas) SetFG(x, y int, color termenv.Color) {
+	if i, ok := c.index(x, y); ok {
+		c.cells[i].FG = color
+	}
+}
+
+// SetBG は座標のマスの背景色だけを置き換えます。
+func (c *Canvas) SetBG(x, y int, color termenv.Color) {
+	if i, ok := c.index(x, y); ok {
+		c.cells[i].BG = color
+	}
+}
+
+// Each は書き込める全てのマスに fn を適用します。
+func (c *Canvas) Each(fn func(x, y int, cell *Cell)) {
+	visible := c.clip.Intersect(c.origin)
+	for ay := visible.Y; ay < visible.Y+visible.Height; ay++ {
+		for ax := visible.X; ax < visible.X+visible.Width; ax++ {
+			fn(ax-c.origin.X, ay-c.origin.Y, &c.cells[ay*c.stride+ax])
+		}
+	}
+}
+
+// Fill は全てのマスを cell で置き換えます。
+func (c *Canvas) Fill(cell Cell) {
+	c.Each(func(_, _ int, dst *Cell) { *dst = cell })
+}
+
+// FillRune は全てのマスの文字を r にします。
+func (c *Canvas) FillRune(r rune) {
+	c.Each(func(_, _ int, dst *Cell) { dst.Rune = r })
+}
+
+// FillFG は全てのマスの文字色を color にします。
+func (c *Canvas) FillFG(color termenv.Color) {
+	c.Each(func(_, _ int, dst *Cell) { dst.FG = color })
+}
+
+// FillBG は全てのマスの背景色を color にします。
+func (c *Canvas) FillBG(color termenv.Color) {
+	c.Each(func(_, _ int, dst *Cell) { dst.BG = color })
+}
+
+// Sub は r の範囲を切り出したキャンバスを返します。
+// 返したキャンバスの原点は r の左上で、書き込みは元のキャンバスの範囲にも制限されます。
+func (c *Canvas) Sub(r Rect) *Canvas {
+	origin := Rect{X: c.origin.X + r.X, Y: c.origin.Y + r.Y, Width: max(r.Width, 0), Height: max(r.Height, 0)}
+	return &Canvas{
+		cells:  c.cells,
+		stride: c.stride,
+		origin: origin,
+		clip:   c.clip.Intersect(origin),
+	}
+}
+
+// Blit は src を (x, y) を左上にして重ねます。
+// src の FG や BG が nil のマスは描画先の色を残します。
+func (c *Canvas) Blit(src *Canvas, x, y int) {
+	src.Each(func(sx, sy int, cell *Cell) {
+		i, ok := c.index(x+sx, y+sy)
+		if !ok {
+			return
+		}
+		dst := &c.cells[i]
+		dst.Rune = cell.Rune
+		dst.Attrs = cell.Attrs
+		if cell.FG != nil {
+			dst.FG = cell.FG
+		}
+		if cell.BG != nil {
+			dst.BG = cell.BG
+		}
+	})
+}
+
+// Clone はキャンバスの書き込める範囲を複製した、独立したキャンバスを返します。
+func (c *Canvas) Clone() *Canvas {
+	clone := NewCanvas(c.Width(), c.Height(), Cell{})
+	c.Each(func(x, y int, cell *Cell) { clone.Set(x, y, *cell) })
+	return clone
+}
+
+// Row は y 行目のマスを左から順に返します。範囲外の行では nil を返します。
+func (c *Canvas) Row(y int) []Cell {
+	if y < 0 || y >= c.Height() {
+		return nil
+	}
+	row := make([]Cell, c.Width())
+	for x := range row {
+		row[x] = c.At(x, y)
+	}
+	return row
+}
+
+// String はキャンバスを端末に出力できる文字列にします。
+func (c *Canvas) String() string {
+	b := strings.Builder{}
+	for y := 0; y < c.Height(); y++ {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		for _, cell := range c.Row(y) {
+			b.WriteString(cell.Style(termenv.String(string(cell.Rune))).String())
+		}
+	}
+	return b.String()
+}
+
+// Text はキャンバスの文字だけを、色や装飾を付けずに返します。行末の空白は取り除きます。
+func (c *Canvas) Text() string {
+	lines := make([]string, c.Height())
+	for y := range lines {
+		line := strings.Builder{}
+		for _, cell := range c.Row(y) {
+			line.WriteRune(cell.Rune)
+		}
+		lines[y] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Style はマスの色と装飾を s に適用します。
+func (cell Cell) Style(s termenv.Style) termenv.Style {
+	if cell.FG != nil {
+		s = s.Foreground(cell.FG)
+	}
+	if cell.BG != nil {
+		s = s.Background(cell.BG)
+	}
+	if cell.Attrs&Bold != 0 {
+		s = s.Bold()
+	}
+	if cell.Attrs&Faint != 0 {
+		s = s.Faint()
+	}
+	if cell.Attrs&Italic != 0 {
+		s = s.Italic()
+	}
+	if cell.Attrs&Underline != 0 {
+		s = s.Underline()
+	}
+	if cell.Attrs&Reverse != 0 {
+		s = s.Reverse()
+	}
+	return s
+}
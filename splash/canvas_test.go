@@ -0,0 +1,116 @@
+package splash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func newDotCanvas(width, height int) *Canvas {
+	return NewCanvas(width, height, Cell{Rune: '.'})
+}
+
+func assertText(t *testing.T, c *Canvas, want ...string) {
+	t.Helper()
+	if got := c.Text(); got != strings.Join(want, "\n") {
+		t.Errorf("got\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestCanvasNestedSubClips(t *testing.T) {
+	c := newDotCanvas(6, 4)
+	outer := c.Sub(Rect{X: 1, Y: 1, Width: 4, Height: 2})
+	// inner は outer からはみ出しているので、outer と重なる2マスにしか書けない
+	inner := outer.Sub(Rect{X: 2, Y: 1, Width: 5, Height: 5})
+	if inner.Width() != 5 || inner.Height() != 5 {
+		t.Fatalf("inner size: got %dx%d, want 5x5", inner.Width(), inner.Height())
+	}
+	inner.FillRune('#')
+	assertText(t, c,
+		"......",
+		"......",
+		"...##.",
+		"......",
+	)
+
+	// 子キャンバスの座標は切り出した範囲の左上が原点になる
+	outer.SetRune(0, 0, 'o')
+	if got := c.At(1, 1).Rune; got != 'o' {
+		t.Errorf("outer (0, 0) wrote to parent (1, 1) as %q, want 'o'", got)
+	}
+	if got := inner.At(3, 0).Rune; got != 0 {
+		t.Errorf("inner.At outside the clip: got %q, want the zero cell", got)
+	}
+}
+
+func TestCanvasDropsWritesOutsideClip(t *testing.T) {
+	c := newDotCanvas(3, 2)
+	sub := c.Sub(Rect{X: 1, Width: 1, Height: 2})
+	for _, p := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 2}, {100, 100}} {
+		sub.SetRune(p[0], p[1], '#')
+		sub.Set(p[0], p[1], Cell{Rune: '#'})
+		sub.SetFG(p[0], p[1], termenv.ANSIRed)
+		sub.SetBG(p[0], p[1], termenv.ANSIRed)
+	}
+	c.SetRune(3, 0, '#')
+	c.SetRune(-1, 1, '#')
+	assertText(t, c, "...", "...")
+	c.Each(func(x, y int, cell *Cell) {
+		if cell.FG != nil || cell.BG != nil {
+			t.Errorf("cell (%d, %d) got a color from a write outside the clip", x, y)
+		}
+	})
+}
+
+func TestCanvasBlitOffsets(t *testing.T) {
+	src := TextCanvas("ab\ncd")
+	tests := []struct {
+		x, y int
+		want []string
+	}{
+		{-1, -1, []string{"d...", "....", "...."}},
+		{3, 2, []string{"....", "....", "...a"}},
+		{1, 0, []string{".ab.", ".cd.", "...."}},
+		{-5, 0, []string{"....", "....", "...."}},
+	}
+	for _, tt := range tests {
+		c := newDotCanvas(4, 3)
+		c.Blit(src, tt.x, tt.y)
+		assertText(t, c, tt.want...)
+	}
+
+	// 色のないマスは描画先の色を残す
+	c := NewCanvas(2, 1, Cell{Rune: '.', FG: termenv.ANSIBlue, BG: termenv.ANSIGreen})
+	c.Blit(TextCanvas("x"), 1, 0)
+	if cell := c.At(1, 0); cell.Rune != 'x' || cell.FG != termenv.ANSIBlue || cell.BG != termenv.ANSIGreen {
+		t.Errorf("blit without colors: got %+v", cell)
+	}
+}
+
+func TestCanvasCloneIsIndependent(t *testing.T) {
+	c := newDotCanvas(3, 2)
+	sub := c.Sub(Rect{X: 1, Y: 1, Width: 2, Height: 2})
+	sub.FillRune('#')
+	clone := sub.Clone()
+
+	// 範囲外だった行は複製ではゼロ値のまま
+	if clone.Width() != 2 || clone.Height() != 2 {
+		t.Fatalf("clone size: got %dx%d, want 2x2", clone.Width(), clone.Height())
+	}
+	if got := clone.At(0, 0).Rune; got != '#' {
+		t.Errorf("clone (0, 0): got %q, want '#'", got)
+	}
+	if got := clone.At(0, 1).Rune; got != 0 {
+		t.Errorf("clone (0, 1) was outside the source: got %q, want the zero cell", got)
+	}
+
+	clone.SetRune(0, 0, 'x')
+	sub.SetRune(1, 0, 'y')
+	if got := c.At(1, 1).Rune; got != '#' {
+		t.Errorf("writing to the clone changed the source: got %q", got)
+	}
+	if got := clone.At(1, 0).Rune; got != '#' {
+		t.Errorf("writing to the source changed the clone: got %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package splash
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Clock はアニメーションが参照する時計です。
+// 本物の時刻の代わりに FakeClock を渡すと、同じフレームを何度でも再現したり実時間より速く書き出したりできます。
+type Clock interface {
+	// Now は現在時刻を返します。
+	Now() time.Time
+	// Tick は d 経ったときに fn の返すメッセージを届けるコマンドを返します。
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+}
+
+// realClock は実際の時刻で動く時計です。
+type realClock struct{}
+
+// RealClock は実際の時刻で動く時計を返します。
+func RealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
+// FakeClock は Add を呼んだときだけ進む時計です。
+// Tick は待たずにすぐ時計を d 進めてメッセージを返します。
+// Bubble Tea はコマンドを別の goroutine で実行するので、時刻は排他制御して読み書きします。
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock は start を指す時計を作ります。
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Add は時計を d 進めます。
+func (c *FakeClock) Add(d time.Duration) {
+	c.add(d)
+}
+
+// add は時計を d 進め、進めた後の時刻を返します。
+func (c *FakeClock) add(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+func (c *FakeClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return fn(c.add(d))
+	}
+}
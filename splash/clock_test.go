@@ -0,0 +1,36 @@
+package splash
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestFakeClockConcurrentTick は Bubble Tea と同じように、Tick のコマンドを別の goroutine で実行しながら Now を読みます。
+// go test -race で実行すると、時刻の読み書きが競合していないことを確かめられます。
+func TestFakeClockConcurrentTick(t *testing.T) {
+	start := time.Time{}
+	clock := NewFakeClock(start)
+	cmd := clock.Tick(FrameInterval, func(now time.Time) tea.Msg { return now })
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			cmd()
+		}()
+		go func() {
+			defer wg.Done()
+			clock.Now()
+		}()
+	}
+	wg.Wait()
+
+	if got, want := clock.Now().Sub(start), n*FrameInterval; got != want {
+		t.Errorf("clock advanced %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,109 @@
+package splash
+
+import (
+	"fmt"
+
+	"github.com/muesli/termenv"
+)
+
+// bayer4 は 4x4 の組織的ディザリングに使う閾値の行列です。
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// ditherSpread はプロファイルごとに隣り合う色どうしがどれだけ離れているかの目安です。
+// ディザリングではこの幅だけ色を揺らしてから最も近い色を選びます。
+var ditherSpread = map[termenv.Profile]float64{
+	termenv.ANSI256: 0.2,
+	termenv.ANSI:    0.5,
+}
+
+// ColorMapper は端末が表示できる色に合わせてキャンバスの色を変換します。
+// TrueColor に対応していない端末では最も近い色を選び、Dither が真なら組織的ディザリングで階調を補います。
+type ColorMapper struct {
+	Profile termenv.Profile
+	Dither  bool
+	cache   map[colorKey]termenv.Color
+}
+
+type colorKey struct {
+	color termenv.Color
+	cell  int
+}
+
+// NewColorMapper は profile に合わせて色を変換する ColorMapper を作ります。
+func NewColorMapper(profile termenv.Profile, dither bool) *ColorMapper {
+	return &ColorMapper{
+		Profile: profile,
+		Dither:  dither,
+		cache:   make(map[colorKey]termenv.Color),
+	}
+}
+
+// ParseColorProfile はコマンドラインで指定されたプロファイル名を解釈します。
+// auto のときは出力先の端末と NO_COLOR などの環境変数から判定し、出力先がなければ TrueColor とします。
+func ParseColorProfile(name string, output *termenv.Output) (termenv.Profile, error) {
+	switch name {
+	case "auto":
+		if output == nil {
+			return termenv.TrueColor, nil
+		}
+		return output.EnvColorProfile(), nil
+	case "truecolor":
+		return termenv.TrueColor, nil
+	case "256":
+		return termenv.ANSI256, nil
+	case "16":
+		return termenv.ANSI, nil
+	case "none":
+		return termenv.Ascii, nil
+	default:
+		return termenv.Ascii, fmt.Errorf("unknown color profile %q (want auto, truecolor, 256, 16 or none)", name)
+	}
+}
+
+// Convert は c の色を変換した新しいキャンバスを返します。TrueColor のときは c をそのまま返します。
+func (p *ColorMapper) Convert(c *Canvas) *Canvas {
+	if p.Profile == termenv.TrueColor {
+		return c
+	}
+	out := c.Clone()
+	out.Each(func(x, y int, cell *Cell) {
+		cell.FG = p.color(cell.FG, x, y)
+		cell.BG = p.color(cell.BG, x, y)
+	})
+	return out
+}
+
+// color は (x, y) のマスに置く色を変換します。色を出せない端末では nil を返します。
+func (p *ColorMapper) color(c termenv.Color, x, y int) termenv.Color {
+	if c == nil || p.Profile == termenv.Ascii {
+		return nil
+	}
+	spread, ok := ditherSpread[p.Profile]
+	if !p.Dither || !ok {
+		return p.lookup(colorKey{c, -1}, func() termenv.Color { return p.Profile.Convert(c) })
+	}
+
+	key := colorKey{c, (y%4)*4 + x%4}
+	return p.lookup(key, func() termenv.Color {
+		rgb := termenv.ConvertToRGB(c)
+		t := ((bayer4[y%4][x%4]+0.5)/16 - 0.5) * spread
+		rgb.R = clamp(rgb.R + t)
+		rgb.G = clamp(rgb.G + t)
+		rgb.B = clamp(rgb.B + t)
+		return p.Profile.Convert(termenv.RGBColor(rgb.Hex()))
+	})
+}
+
+func (p *ColorMapper) lookup(key colorKey, convert func() termenv.Color) termenv.Color {
+	if color, ok := p.cache[key]; ok {
+		return color
+	}
+	color := convert()
+	p.cache[key] = color
+	return color
+}
@@ -0,0 +1,163 @@
+package splash
+
+import (
+	"fmt"
+	"math"
+)
+
+// Curve は直前の頂点からこの頂点までをつなぐ曲線の指定です。
+// Controls に制御点を1つ書くと2次、2つ書くと3次のベジェ曲線になります。
+// Radius を書くと半径 Radius の円弧になります。向きと大きい方の弧を選ぶかどうかは SVG の円弧と同じ意味です。
+// 座標はどれもマス目の単位です。
+type Curve struct {
+	Controls  []ControlPoint `json:"controls,omitempty"`
+	Radius    float64        `json:"radius,omitempty"`
+	Clockwise bool           `json:"clockwise,omitempty"` // Clockwise は画面上で時計回りに弧を描くかどうかです。
+	LargeArc  bool           `json:"largeArc,omitempty"`  // LargeArc は2通りの弧のうち長い方を選ぶかどうかです。
+}
+
+// ControlPoint はベジェ曲線の制御点です。
+// 曲線はマスに丸める前の形で細かい直線に分けるので、頂点と違ってマスの途中の位置も書けます。
+type ControlPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// validatePaths は曲線の指定が正しいかを調べます。誤りはどの頂点かが分かるエラーとして返します。
+func validatePaths(paths [][]Vertex) error {
+	for i, path := range paths {
+		for j, v := range path {
+			if v.Curve == nil {
+				continue
+			}
+			field := fmt.Sprintf("[%d][%d].curve", i, j)
+			if j == 0 {
+				return fmt.Errorf("%s: the first vertex of a path cannot have a curve", field)
+			}
+			if err := v.Curve.validate(); err != nil {
+				return fmt.Errorf("%s: %w", field, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Curve) validate() error {
+	if len(c.Controls) > 2 {
+		return fmt.Errorf("want 1 or 2 control points, got %d", len(c.Controls))
+	}
+	if c.Radius < 0 {
+		return fmt.Errorf("radius must not be negative, got %g", c.Radius)
+	}
+	switch {
+	case len(c.Controls) > 0 && c.Radius > 0:
+		return fmt.Errorf("controls and radius cannot be used together")
+	case len(c.Controls) == 0 && c.Radius == 0:
+		return fmt.Errorf("either controls or radius is required")
+	}
+	return nil
+}
+
+// flattenPath は曲線を含む折れ線を、短い直線だけでつないだ折れ線に直します。
+// 曲線の上の点はマスに丸めるので、返す頂点はどれも Curve を持ちません。
+func flattenPath(line []Vertex) []Vertex {
+	points := make([]Vertex, 0, len(line))
+	for i, v := range line {
+		end := Vertex{X: v.X, Y: v.Y}
+		if i == 0 || v.Curve == nil {
+			points = append(points, end)
+			continue
+		}
+		prev := line[i-1]
+		for _, p := range v.Curve.sample(prev, v) {
+			q := Vertex{X: int(math.Round(p[0])), Y: int(math.Round(p[1]))}
+			if last := points[len(points)-1]; q.X != last.X || q.Y != last.Y {
+				points = append(points, q)
+			}
+		}
+		// 丸めの誤差があっても曲線は必ず頂点で終わる
+		if last := points[len(points)-1]; last.X != end.X || last.Y != end.Y {
+			points = append(points, end)
+		}
+	}
+	return points
+}
+
+// sample は from から to までの曲線の上の点を、始点を除いて順に返します。
+// 隣り合う点がおよそ半マス以内に収まる細かさで取ります。
+func (c *Curve) sample(from, to Vertex) [][2]float64 {
+	if c.Radius > 0 {
+		return sampleArc(from, to, c.Radius, c.Clockwise, c.LargeArc)
+	}
+	ps := [][2]float64{vertexPoint(from)}
+	for _, p := range c.Controls {
+		ps = append(ps, [2]float64{p.X, p.Y})
+	}
+	ps = append(ps, vertexPoint(to))
+
+	// 制御点を結んだ折れ線の長さは曲線の長さ以上になる
+	length := 0.0
+	for i := 1; i < len(ps); i++ {
+		length += math.Hypot(ps[i][0]-ps[i-1][0], ps[i][1]-ps[i-1][1])
+	}
+	n := max(int(math.Ceil(length*2)), 1)
+	result := make([][2]float64, 0, n)
+	for k := 1; k <= n; k++ {
+		result = append(result, bezier(ps, float64(k)/float64(n)))
+	}
+	return result
+}
+
+// bezier は制御点 ps のベジェ曲線の t における点を de Casteljau の方法で求めます。
+func bezier(ps [][2]float64, t float64) [2]float64 {
+	work := append([][2]float64(nil), ps...)
+	for n := len(work) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			work[i][0] = lerp(work[i][0], work[i+1][0], t)
+			work[i][1] = lerp(work[i][1], work[i+1][1], t)
+		}
+	}
+	return work[0]
+}
+
+// sampleArc は SVG の円弧と同じ規則で from から to への円弧の中心を求め、弧の上の点を返します。
+// 半径が2点を結ぶのに足りなければ、SVG と同じようにちょうど届く大きさまで広げます。
+func sampleArc(from, to Vertex, radius float64, clockwise, largeArc bool) [][2]float64 {
+	x1, y1 := float64(from.X), float64(from.Y)
+	x2, y2 := float64(to.X), float64(to.Y)
+	hx, hy := (x1-x2)/2, (y1-y2)/2
+	d2 := hx*hx + hy*hy
+	if d2 == 0 {
+		return nil
+	}
+	r := math.Max(radius, math.Sqrt(d2))
+
+	// 2点の中点から中心までの距離を弦に垂直な向きに取る
+	coef := math.Sqrt(math.Max(0, (r*r-d2)/d2))
+	if largeArc == clockwise {
+		coef = -coef
+	}
+	cx := coef*hy + (x1+x2)/2
+	cy := -coef*hx + (y1+y2)/2
+
+	// y 軸が下向きなので、角度が増える向きが画面上の時計回りになる
+	start := math.Atan2(y1-cy, x1-cx)
+	sweep := math.Atan2(y2-cy, x2-cx) - start
+	if clockwise && sweep < 0 {
+		sweep += 2 * math.Pi
+	} else if !clockwise && sweep > 0 {
+		sweep -= 2 * math.Pi
+	}
+
+	n := max(int(math.Ceil(math.Abs(sweep)*r*2)), 1)
+	result := make([][2]float64, 0, n)
+	for k := 1; k <= n; k++ {
+		a := start + sweep*float64(k)/float64(n)
+		result = append(result, [2]float64{cx + r*math.Cos(a), cy + r*math.Sin(a)})
+	}
+	return result
+}
+
+func vertexPoint(v Vertex) [2]float64 {
+	return [2]float64{float64(v.X), float64(v.Y)}
+}
@@ -0,0 +1,57 @@
+package splash
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCurvedPathIsConnected(t *testing.T) {
+	paths := map[string][]Vertex{
+		"quadratic": {{X: 0, Y: 0}, {X: 20, Y: 10, Curve: &Curve{Controls: []ControlPoint{{X: 20, Y: 0}}}}},
+		"cubic":     {{X: 0, Y: 0}, {X: 30, Y: 0, Curve: &Curve{Controls: []ControlPoint{{X: 0, Y: 15}, {X: 30, Y: -15}}}}},
+		"arc":       {{X: 0, Y: 0}, {X: 10, Y: 10, Curve: &Curve{Radius: 10, Clockwise: true}}, {X: 20, Y: 10}},
+	}
+	for name, line := range paths {
+		t.Run(name, func(t *testing.T) {
+			points := rasterizePath(line)
+			for i := 1; i < len(points); i++ {
+				dx, dy := abs(points[i].X-points[i-1].X), abs(points[i].Y-points[i-1].Y)
+				if max(dx, dy) != 1 {
+					t.Errorf("points %v and %v are not adjacent", points[i-1], points[i])
+				}
+			}
+		})
+	}
+}
+
+func TestArcFollowsCircle(t *testing.T) {
+	// (0,0) から (10,10) への時計回りの円弧の中心は (0,10) になる
+	line := []Vertex{{X: 0, Y: 0}, {X: 10, Y: 10, Curve: &Curve{Radius: 10, Clockwise: true}}}
+	for _, p := range rasterizePath(line) {
+		if d := math.Hypot(float64(p.X), float64(p.Y-10)); math.Abs(d-10) > 1 {
+			t.Errorf("point %v is %.2f away from the center, want about 10", p, d)
+		}
+		if p.X < 0 || p.Y > 10 {
+			t.Errorf("point %v is outside the quarter circle", p)
+		}
+	}
+}
+
+func TestValidatePaths(t *testing.T) {
+	tests := []struct {
+		path []Vertex
+		want string
+	}{
+		{[]Vertex{{X: 0, Y: 0, Curve: &Curve{Radius: 1}}}, "[0][0].curve: the first vertex"},
+		{[]Vertex{{}, {X: 1, Curve: &Curve{}}}, "[0][1].curve: either controls or radius"},
+		{[]Vertex{{}, {X: 1, Curve: &Curve{Radius: 1, Controls: []ControlPoint{{}}}}}, "cannot be used together"},
+		{[]Vertex{{}, {X: 1, Curve: &Curve{Controls: make([]ControlPoint, 3)}}}, "want 1 or 2 control points"},
+	}
+	for _, tt := range tests {
+		err := validatePaths([][]Vertex{tt.path})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("got %v, want an error containing %q", err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package splash
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FIGlet の横方向の配置の規則を表すビットです。
+const (
+	figletEqual     = 1   // 同じ文字どうしを重ねる
+	figletLowline   = 2   // _ を他の線の文字に置き換える
+	figletHierarchy = 4   // | /\ [] {} () <> の順に強い方を残す
+	figletPair      = 8   // 向かい合う括弧を | にする
+	figletBigX      = 16  // /\ を |、\/ を Y、>< を X にする
+	figletHardblank = 32  // hardblank どうしを重ねる
+	figletKerning   = 64  // 文字が触れるまで詰める
+	figletSmushing  = 128 // 文字を1列重ねる
+)
+
+// figletDeutsch は必須の ASCII の後に並ぶ7文字です。
+var figletDeutsch = []rune{196, 214, 220, 228, 246, 252, 223}
+
+// FIGletFont は FIGlet の .flf 形式のフォントです。
+type FIGletFont struct {
+	Height    int // Height は1文字の行数です。
+	Baseline  int // Baseline は上から数えたベースラインの行数です。
+	hardblank rune
+	layout    int
+	glyphs    map[rune][]string
+}
+
+// LoadFIGletFont は path の .flf ファイルを読み込みます。
+func LoadFIGletFont(path string) (*FIGletFont, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening FIGlet font: %w", err)
+	}
+	defer file.Close()
+	font, err := ParseFIGletFont(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return font, nil
+}
+
+// ParseFIGletFont は .flf 形式のフォントを読み込みます。
+func ParseFIGletFont(r io.Reader) (*FIGletFont, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+	line := 0
+	next := func() (string, bool) {
+		if !scanner.Scan() {
+			return "", false
+		}
+		line++
+		return strings.TrimRight(scanner.Text(), "\r"), true
+	}
+
+	header, ok := next()
+	if !ok || !strings.HasPrefix(header, "flf2a") || len(header) < 6 {
+		return nil, fmt.Errorf("not a FIGlet font (missing flf2a header)")
+	}
+	fields := strings.Fields(header[6:])
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("line 1: header has %d fields, want at least 5", len(fields))
+	}
+	params := make([]int, len(fields))
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("line 1: header field %d: %w", i+1, err)
+		}
+		params[i] = n
+	}
+	font := &FIGletFont{
+		Height:    params[0],
+		Baseline:  params[1],
+		hardblank: []rune(header)[5],
+		glyphs:    make(map[rune][]string),
+	}
+	if font.Height <= 0 {
+		return nil, fmt.Errorf("line 1: height must be positive, got %d", font.Height)
+	}
+	font.layout = figletLayout(params[3], params[5:])
+
+	for i := 0; i < params[4]; i++ {
+		if _, ok := next(); !ok {
+			return nil, fmt.Errorf("unexpected end of file in comment")
+		}
+	}
+
+	readGlyph := func(code rune) error {
+		rows := make([]string, font.Height)
+		for i := range rows {
+			row, ok := next()
+			if !ok {
+				return fmt.Errorf("unexpected end of file in glyph %q", code)
+			}
+			// figlet の readfontchar と同じく、行末の空白を除いてから
+			// endmark (残った行の最後の文字。文字の最終行では2つ続く) を取り除く
+			row = strings.TrimRightFunc(row, unicode.IsSpace)
+			if endmark, size := utf8.DecodeLastRuneInString(row); size > 0 {
+				row = strings.TrimRight(row, string(endmark))
+			}
+			rows[i] = row
+		}
+		font.glyphs[code] = rows
+		return nil
+	}
+
+	for code := rune(32); code <= 126; code++ {
+		if err := readGlyph(code); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	// ドイツ語の文字とコード付きの文字は省略できる
+	for _, code := range figletDeutsch {
+		if err := readGlyph(code); err != nil {
+			return font, nil
+		}
+	}
+	for {
+		tag, ok := next()
+		if !ok {
+			return font, nil
+		}
+		fields := strings.Fields(tag)
+		if len(fields) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(fields[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid character code %q", line, fields[0])
+		}
+		if err := readGlyph(rune(code)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// figletLayout はヘッダーの old_layout と、あれば full_layout から横方向の配置の規則を求めます。
+func figletLayout(oldLayout int, rest []int) int {
+	// rest は print_direction, full_layout, codetag_count の順
+	if len(rest) >= 2 {
+		return rest[1] & 0xff
+	}
+	switch {
+	case oldLayout < 0:
+		return 0
+	case oldLayout == 0:
+		return figletKerning
+	default:
+		return oldLayout&63 | figletSmushing
+	}
+}
+
+// Render は text をフォントの規則に従って横に並べ、ロゴとして使える複数行の文字列にします。
+// フォントにない文字があれば、コード 0 の文字で代用するか、なければエラーを返します。
+func (f *FIGletFont) Render(text string) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("text is empty")
+	}
+	out := make([][]rune, f.Height)
+	prevWidth := 0
+	for _, r := range text {
+		glyph, ok := f.glyphs[r]
+		if !ok {
+			glyph, ok = f.glyphs[0]
+		}
+		if !ok {
+			return "", fmt.Errorf("no glyph for %q", r)
+		}
+		char := make([][]rune, f.Height)
+		width := 0
+		for i, row := range glyph {
+			char[i] = []rune(row)
+			width = max(width, len(char[i]))
+		}
+
+		amount := f.smushAmount(out, char, prevWidth, width)
+		for i := range out {
+			length := len(out[i])
+			for k := 0; k < amount && k < len(char[i]); k++ {
+				if at := length - amount + k; at >= 0 {
+					out[i][at] = f.smush(out[i][at], char[i][k], prevWidth, width)
+				}
+			}
+			if amount < len(char[i]) {
+				out[i] = append(out[i], char[i][amount:]...)
+			}
+		}
+		prevWidth = width
+	}
+
+	lines := make([]string, len(out))
+	for i, row := range out {
+		lines[i] = strings.ReplaceAll(string(row), string(f.hardblank), " ")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// smushAmount は out の右に char を置くとき、何列重ねられるかを返します。
+func (f *FIGletFont) smushAmount(out, char [][]rune, prevWidth, width int) int {
+	if f.layout&(figletSmushing|figletKerning) == 0 {
+		return 0
+	}
+	amount := width
+	for i := range out {
+		// out の行の右端の空白でない文字と、char の行の左端の空白でない文字の位置を探す
+		lineEnd := max(len(out[i])-1, 0)
+		for lineEnd > 0 && out[i][lineEnd] == ' ' {
+			lineEnd--
+		}
+		charStart := 0
+		for charStart < len(char[i]) && char[i][charStart] == ' ' {
+			charStart++
+		}
+		n := charStart + len(out[i]) - 1 - lineEnd
+
+		var left, right rune = ' ', 0
+		if lineEnd < len(out[i]) {
+			left = out[i][lineEnd]
+		}
+		if charStart < len(char[i]) {
+			right = char[i][charStart]
+		}
+		if left == ' ' {
+			n++
+		} else if right != 0 && f.smush(left, right, prevWidth, width) != 0 {
+			n++
+		}
+		amount = min(amount, n)
+	}
+	return max(amount, 0)
+}
+
+// smush は左の文字 left と右の文字 right を重ねた結果を返します。重ねられなければ 0 を返します。
+func (f *FIGletFont) smush(left, right rune, prevWidth, width int) rune {
+	if left == ' ' {
+		return right
+	}
+	if right == ' ' {
+		return left
+	}
+	// 幅が1以下の文字は重ねない
+	if prevWidth < 2 || width < 2 {
+		return 0
+	}
+	if f.layout&figletSmushing == 0 {
+		return 0
+	}
+	if f.layout&63 == 0 {
+		// 規則がなければ見える文字を優先して右の文字を残す
+		if left == f.hardblank {
+			return right
+		}
+		if right == f.hardblank {
+			return left
+		}
+		return right
+	}
+
+	if f.layout&figletHardblank != 0 && left == f.hardblank && right == f.hardblank {
+		return left
+	}
+	if left == f.hardblank || right == f.hardblank {
+		return 0
+	}
+	if f.layout&figletEqual != 0 && left == right {
+		return left
+	}
+	if f.layout&figletLowline != 0 {
+		if left == '_' && strings.ContainsRune(`|/\[]{}()<>`, right) {
+			return right
+		}
+		if right == '_' && strings.ContainsRune(`|/\[]{}()<>`, left) {
+			return left
+		}
+	}
+	if f.layout&figletHierarchy != 0 {
+		// 後ろのクラスほど強い
+		classes := []string{"|", `/\`, "[]", "{}", "()", "<>"}
+		rank := func(r rune) int {
+			for i, class := range classes {
+				if strings.ContainsRune(class, r) {
+					return i
+				}
+			}
+			return -1
+		}
+		if l, r := rank(left), rank(right); l >= 0 && r >= 0 && l != r {
+			if l > r {
+				return left
+			}
+			return right
+		}
+	}
+	if f.layout&figletPair != 0 {
+		switch string([]rune{left, right}) {
+		case "[]", "][", "{}", "}{", "()", ")(":
+			return '|'
+		}
+	}
+	if f.layout&figletBigX != 0 {
+		switch string([]rune{left, right}) {
+		case `/\`:
+			return '|'
+		case `\/`:
+			return 'Y'
+		case "><":
+			return 'X'
+		}
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package splash
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testFIGletFont は高さ2行で、l と o だけに字形のあるフォントを作ります。
+func testFIGletFont(t *testing.T, oldLayout int) *FIGletFont {
+	t.Helper()
+	font, err := ParseFIGletFont(strings.NewReader(testFIGletSource(oldLayout, "")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return font
+}
+
+// testFIGletSource は testFIGletFont の .flf の中身を、各行の endmark の後に trailing を付けて書きます。
+func testFIGletSource(oldLayout int, trailing string) string {
+	glyphs := map[rune][2]string{
+		'l': {"| ", "| "},
+		'o': {" o", " o"},
+	}
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "flf2a$ 2 2 4 %d 1\ntest font\n", oldLayout)
+	for code := rune(32); code <= 126; code++ {
+		glyph, ok := glyphs[code]
+		if !ok {
+			glyph = [2]string{"$ ", "$ "}
+		}
+		fmt.Fprintf(&b, "%s@%s\n%s@@%s\n", glyph[0], trailing, glyph[1], trailing)
+	}
+	return b.String()
+}
+
+func TestFIGletLayout(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldLayout int
+		text      string
+		want      string
+	}{
+		{"full width", -1, "lo", "|  o\n|  o"},
+		{"kerning", 0, "lo", "|o\n|o"},
+		{"smushing equal characters", 1, "ll", "| \n| "},
+		{"hardblanks keep a gap", 1, "l l", "|$| \n|$| "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testFIGletFont(t, tt.oldLayout).Render(tt.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := strings.ReplaceAll(tt.want, "$", " ")
+			if got != want {
+				t.Errorf("Render(%q) = %q, want %q", tt.text, got, want)
+			}
+		})
+	}
+}
+
+func TestFIGletTrailingWhitespaceAfterEndmark(t *testing.T) {
+	font, err := ParseFIGletFont(strings.NewReader(testFIGletSource(0, " \t ")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := font.Render("lo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "|o\n|o"; got != want {
+		t.Errorf("Render(%q) = %q, want %q", "lo", got, want)
+	}
+}
@@ -0,0 +1,607 @@
+package splash
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// DefaultText は既定のロゴに書く文字列です。
+const DefaultText = "Charm"
+
+// DefaultSpacing はブロック文字のロゴで文字と文字の間に空ける既定の列数です。
+const DefaultSpacing = 3
+
+// MinSpacing と MaxSpacing はシーンファイルで指定できる字間の範囲です。
+// MinSpacing は最も幅の狭いブロック文字の幅で、これより詰めると後の文字が前の文字より左に来てしまいます。
+const (
+	MinSpacing = -2
+	MaxSpacing = 32
+)
+
+// blockGlyphs は文字ごとのブロック文字の字形です。
+// 大文字と数字の高さは10行で、小文字は x ハイトの7行に、上に伸びる部分があればその行を加えた高さです。
+// 最終行がベースラインに乗るので、ベースラインより下に伸びる文字は blockDescenders にその行数を書きます。
+var blockGlyphs = map[rune]string{
+	'C': C,
+	'h': H,
+	'a': A,
+	'r': R,
+	'm': M,
+	'A': `    ◢██◣    
+   ◢████◣   
+  ◢██◤◥██◣  
+  ██◤  ◥██  
+ ◢██    ██◣ 
+ ██◤    ◥██ 
+◢██████████◣
+██◤      ◥██
+██        ██
+██        ██`,
+	'B': `█████████◣  
+██      ◥██ 
+██       ██ 
+██      ◢██ 
+█████████◤  
+██      ◥██◣
+██        ██
+██        ██
+██      ◢██◤
+█████████◤  `,
+	'D': `████████◣   
+██     ◥██◣ 
+██       ██ 
+██       ███
+██        ██
+██        ██
+██       ███
+██       ██ 
+██     ◢██◤ 
+████████◤   `,
+	'E': `███████████
+██         
+██         
+██         
+██████████ 
+██         
+██         
+██         
+██         
+███████████`,
+	'F': `███████████
+██         
+██         
+██         
+██████████ 
+██         
+██         
+██         
+██         
+██         `,
+	'G': ` ◢████████◣ 
+◢██◤     ◥██
+██◤         
+██          
+██    ██████
+██        ██
+██        ██
+██◣       ██
+◥██◣     ◢██
+ ◥████████◤ `,
+	'H': `██        ██
+██        ██
+██        ██
+██        ██
+████████████
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██`,
+	'I': `██████
+  ██  
+  ██  
+  ██  
+  ██  
+  ██  
+  ██  
+  ██  
+  ██  
+██████`,
+	'J': `      ██
+      ██
+      ██
+      ██
+      ██
+      ██
+      ██
+◢█    ██
+◥██◣ ◢██
+ ◥████◤ `,
+	'K': `██      ◢██◤
+██     ◢██◤ 
+██    ◢██◤  
+██   ◢██◤   
+██████◤     
+██████◣     
+██   ◥██◣   
+██    ◥██◣  
+██     ◥██◣ 
+██      ◥██◣`,
+	'L': `██        
+██        
+██        
+██        
+██        
+██        
+██        
+██        
+██        
+██████████`,
+	'M': `███◣    ◢███
+████◣  ◢████
+██◥██◣◢██◤██
+██ ◥████◤ ██
+██  ◥██◤  ██
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██`,
+	'N': `███◣      ██
+████◣     ██
+██◥██◣    ██
+██ ◥██◣   ██
+██  ◥██◣  ██
+██   ◥██◣ ██
+██    ◥██◣██
+██     ◥████
+██      ◥███
+██       ◥██`,
+	'O': ` ◢████████◣ 
+◢██◤    ◥██◣
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+◥██◣    ◢██◤
+ ◥████████◤ `,
+	'P': `█████████◣  
+██      ◥██ 
+██       ██ 
+██       ██ 
+██      ◢██ 
+█████████◤  
+██          
+██          
+██          
+██          `,
+	'Q': ` ◢████████◣ 
+◢██◤    ◥██◣
+██        ██
+██        ██
+██        ██
+██        ██
+██    ◥◣  ██
+◥██◣   ◥██◤ 
+ ◥████████◣ 
+          ◥◣`,
+	'R': `█████████◣  
+██      ◥██ 
+██       ██ 
+██      ◢██ 
+█████████◤  
+██    ◥██◣  
+██     ◥██  
+██      ◥██ 
+██       ██ 
+██       ◥██`,
+	'S': ` ◢████████◣ 
+◢██◤     ◥██
+██◣         
+◥███◣       
+ ◥███████◣  
+       ◥███◣
+         ◥██
+◢█       ◢██
+◥██◣    ◢██◤
+ ◥███████◤  `,
+	'T': `████████████
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     `,
+	'U': `██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+██        ██
+◥██◣    ◢██◤
+ ◥████████◤ `,
+	'V': `██        ██
+██        ██
+◥██      ██◤
+ ██      ██ 
+ ◥██    ██◤ 
+  ██    ██  
+  ◥██  ██◤  
+   ██  ██   
+   ◥████◤   
+    ◥██◤    `,
+	'W': `██        ██
+██        ██
+██        ██
+██        ██
+██   ██   ██
+██  ◢██◣  ██
+██ ◢████◣ ██
+██◢██◤◥██◣██
+████◤  ◥████
+███◤    ◥███`,
+	'X': `◥██◣    ◢██◤
+ ◥██◣  ◢██◤ 
+  ◥██◣◢██◤  
+   ◥████◤   
+    ████    
+   ◢████◣   
+  ◢██◤◥██◣  
+ ◢██◤  ◥██◣ 
+◢██◤    ◥██◣
+██◤      ◥██`,
+	'Y': `◥██◣    ◢██◤
+ ◥██◣  ◢██◤ 
+  ◥██◣◢██◤  
+   ◥████◤   
+    ◥██◤    
+     ██     
+     ██     
+     ██     
+     ██     
+     ██     `,
+	'Z': `████████████
+        ◢██◤
+       ◢██◤ 
+      ◢██◤  
+     ◢██◤   
+    ◢██◤    
+   ◢██◤     
+  ◢██◤      
+ ◢██◤       
+████████████`,
+	'0': ` ◢██████◣ 
+◢██◤  ◥██◣
+██      ██
+██      ██
+██      ██
+██      ██
+██      ██
+██      ██
+◥██◣  ◢██◤
+ ◥██████◤ `,
+	'1': `   ◢██    
+  ◢███    
+ ◢█◤██    
+    ██    
+    ██    
+    ██    
+    ██    
+    ██    
+    ██    
+ ████████ `,
+	'2': ` ◢██████◣ 
+◢██◤  ◥██◣
+       ██ 
+      ◢██ 
+     ◢██◤ 
+    ◢██◤  
+   ◢██◤   
+  ◢██◤    
+ ◢██◤     
+██████████`,
+	'3': ` ◢██████◣ 
+◢██◤  ◥██◣
+       ◢██
+   ◢████◤ 
+   ◥████◣ 
+       ◥██
+        ██
+◢█      ██
+◥██◣  ◢██◤
+ ◥██████◤ `,
+	'4': `     ◢███ 
+    ◢████ 
+   ◢██◤██ 
+  ◢██◤ ██ 
+ ◢██◤  ██ 
+◢██◤   ██ 
+██████████
+       ██ 
+       ██ 
+       ██ `,
+	'5': `██████████
+██        
+██        
+████████◣ 
+      ◥██◣
+        ██
+        ██
+◢█      ██
+◥██◣  ◢██◤
+ ◥██████◤ `,
+	'6': ` ◢██████◣ 
+◢██◤   ◥█ 
+██        
+██        
+████████◣ 
+██◤   ◥██◣
+██      ██
+██      ██
+◥██◣  ◢██◤
+ ◥██████◤ `,
+	'7': `██████████
+       ◢██
+      ◢██◤
+     ◢██◤ 
+    ◢██◤  
+    ██◤   
+   ◢██    
+   ██◤    
+   ██     
+   ██     `,
+	'8': ` ◢██████◣ 
+◢██◤  ◥██◣
+██      ██
+◥██◣  ◢██◤
+ ◢██████◣ 
+◢██◤  ◥██◣
+██      ██
+██      ██
+◥██◣  ◢██◤
+ ◥██████◤ `,
+	'9': ` ◢██████◣ 
+◢██◤  ◥██◣
+██      ██
+◥██◣  ◢███
+ ◥█████◤██
+        ██
+        ██
+      ◢██◤
+◥█◣  ◢██◤ 
+ ◥████◤   `,
+	' ': `      
+      
+      
+      
+      
+      
+      
+      
+      
+      `,
+	'.': `  
+  
+  
+  
+  
+  
+  
+  
+██
+██`,
+	',': `  
+  
+  
+  
+  
+  
+  
+  
+██
+◢◤`,
+	'!': `██
+██
+██
+██
+██
+██
+██
+  
+██
+██`,
+	':': `  
+  
+██
+██
+  
+  
+  
+  
+██
+██`,
+	'\'': `██
+◢◤
+  
+  
+  
+  
+  
+  
+  
+  `,
+	'-': `      
+      
+      
+      
+██████
+      
+      
+      
+      
+      `,
+	'+': `      
+      
+  ██  
+  ██  
+██████
+  ██  
+  ██  
+      
+      
+      `,
+	'?': ` ◢██████◣ 
+◢██◤  ◥██◣
+      ◢██◤
+    ◢██◤  
+    ██    
+    ██    
+          
+          
+    ██    
+    ██    `,
+	'/': `        ◢█
+       ◢█◤
+      ◢█◤ 
+     ◢█◤  
+    ◢█◤   
+   ◢█◤    
+  ◢█◤     
+ ◢█◤      
+◢█◤       
+█◤        `,
+}
+
+// blockDescenders はベースラインより下に伸びる文字と、その行数です。
+var blockDescenders = map[rune]int{
+	'Q': 1,
+	',': 1,
+}
+
+// blockKerning は隣り合う2文字の間隔を、字間から増減する列数です。
+// 斜めの線どうしのように、字形の箱の端に隙間のある組み合わせを詰めます。
+var blockKerning = map[[2]rune]int{
+	{'A', 'V'}: -2,
+	{'V', 'A'}: -2,
+	{'A', 'Y'}: -2,
+	{'Y', 'A'}: -2,
+	{'A', 'T'}: -3,
+	{'T', 'A'}: -3,
+	{'L', 'T'}: -4,
+	{'L', 'V'}: -2,
+	{'L', 'Y'}: -3,
+	{'P', 'A'}: -2,
+	{'F', 'A'}: -2,
+	{'/', '/'}: -6,
+}
+
+// Glyph はロゴの1文字分の字形です。
+type Glyph struct {
+	Rows     []string // Rows は字形の各行です。
+	Width    int      // Width は字形の表示幅です。
+	Baseline int      // Baseline はベースラインより上にある行数です。残りの行はベースラインの下に伸びます。
+}
+
+// cellWidth は文字の幅を測る条件です。ブロック要素 (█▀▄) を常に1マスと数えるので、
+// Canvas と同じく1文字1マスで進み、組み込むアプリのロケールや runewidth.DefaultCondition に左右されません。
+var cellWidth = &runewidth.Condition{EastAsianWidth: false}
+
+// newGlyph は複数行の文字列から、幅を cellWidth で測った字形を作ります。
+func newGlyph(art string, descent int) Glyph {
+	rows := splitIntoLines(art)
+	width := 0
+	for _, row := range rows {
+		width = max(width, cellWidth.StringWidth(row))
+	}
+	return Glyph{Rows: rows, Width: width, Baseline: len(rows) - descent}
+}
+
+// lookupGlyph は r の字形を返します。字形のない小文字は大文字の字形で、大文字は小文字の字形で代用します。
+// 代用したときは代わりに使った文字も返すので、カーニングはその文字で引きます。
+func lookupGlyph(r rune) (Glyph, rune, bool) {
+	for _, c := range []rune{r, unicode.ToUpper(r), unicode.ToLower(r)} {
+		if art, ok := blockGlyphs[c]; ok {
+			return newGlyph(art, blockDescenders[c]), c, true
+		}
+	}
+	return Glyph{}, 0, false
+}
+
+// TextLayout はブロック文字でロゴを組むときの設定です。
+type TextLayout struct {
+	Spacing int // Spacing は文字と文字の間に空ける列数です。負の値なら字形の箱が重なります。
+}
+
+// LayoutText は text を既定の字間でブロック文字のロゴにします。
+func LayoutText(text string) (string, error) {
+	return TextLayout{Spacing: DefaultSpacing}.Layout(text)
+}
+
+// Layout は text の各文字をブロック文字の字形に置き換え、ベースラインを揃えて横に並べたロゴを返します。
+// 字形の間隔は Spacing に文字の組ごとのカーニングを足したものです。字形のない文字が含まれていればエラーを返します。
+func (l TextLayout) Layout(text string) (string, error) {
+	if text == "" {
+		return "", fmt.Errorf("text is empty")
+	}
+	var glyphs []Glyph
+	var xs []int
+	x, above, below := 0, 0, 0
+	var prev rune
+	for i, r := range []rune(text) {
+		glyph, c, ok := lookupGlyph(r)
+		if !ok {
+			return "", fmt.Errorf("no glyph for %q", r)
+		}
+		if i > 0 {
+			x += glyphs[i-1].Width + l.Spacing + blockKerning[[2]rune{prev, c}]
+		}
+		glyphs = append(glyphs, glyph)
+		xs = append(xs, x)
+		above = max(above, glyph.Baseline)
+		below = max(below, len(glyph.Rows)-glyph.Baseline)
+		prev = c
+	}
+
+	width := 0
+	for i, glyph := range glyphs {
+		width = max(width, xs[i]+glyph.Width)
+	}
+	// 全角の文字の右半分は 0 にしておき、書き出すときに飛ばす
+	grid := make([][]rune, above+below)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", width))
+	}
+	for i, glyph := range glyphs {
+		top := above - glyph.Baseline
+		for y, row := range glyph.Rows {
+			column := xs[i]
+			for _, r := range row {
+				w := cellWidth.RuneWidth(r)
+				// 重なったところでは、空白で前の文字を消さない
+				if r != ' ' && column >= 0 {
+					grid[top+y][column] = r
+					for k := 1; k < w && column+k < width; k++ {
+						grid[top+y][column+k] = 0
+					}
+				}
+				column += w
+			}
+		}
+	}
+
+	lines := make([]string, len(grid))
+	for y, row := range grid {
+		lines[y] = strings.ReplaceAll(string(row), "\x00", "")
+	}
+	return strings.Join(lines, "\n"), nil
+}
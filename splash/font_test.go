@@ -0,0 +1,68 @@
+package splash
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func TestLayoutAlignsBaselines(t *testing.T) {
+	logo, err := LayoutText("Ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(logo, "\n")
+	if len(lines) != 10 {
+		t.Fatalf("got %d lines, want 10", len(lines))
+	}
+	// a は x ハイトの字形なので、上の3行には何も書かれず、最終行は C と揃う
+	c := newGlyph(C, 0)
+	for y, line := range lines {
+		right := strings.TrimSpace(string([]rune(line)[c.Width+DefaultSpacing:]))
+		if (y < 3) != (right == "") {
+			t.Errorf("line %d: a is drawn as %q", y, right)
+		}
+	}
+}
+
+func TestLayoutSpacingAndKerning(t *testing.T) {
+	width := func(layout TextLayout, text string) int {
+		logo, err := layout.Layout(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cellWidth.StringWidth(strings.Split(logo, "\n")[0])
+	}
+	a, _, _ := lookupGlyph('A')
+	h, _, _ := lookupGlyph('H')
+	v, _, _ := lookupGlyph('V')
+
+	if got, want := width(TextLayout{Spacing: 1}, "AH"), a.Width+1+h.Width; got != want {
+		t.Errorf("AH with spacing 1: width %d, want %d", got, want)
+	}
+	if got, want := width(TextLayout{Spacing: 3}, "AV"), a.Width+3+blockKerning[[2]rune{'A', 'V'}]+v.Width; got != want {
+		t.Errorf("AV with kerning: width %d, want %d", got, want)
+	}
+}
+
+func TestLayoutIgnoresEastAsianWidthSetting(t *testing.T) {
+	draw := func() []byte {
+		m := NewSlideModel(DefaultScene())
+		m.Seek(m.PhaseIndex("Horizontal"), 0.5)
+		return serializeCanvas(m.Draw())
+	}
+	want := draw()
+
+	// CJK のロケールで動くアプリでは、ブロック要素が2マスとして数えられる
+	saved := runewidth.DefaultCondition.EastAsianWidth
+	runewidth.DefaultCondition.EastAsianWidth = true
+	defer func() { runewidth.DefaultCondition.EastAsianWidth = saved }()
+	if runewidth.StringWidth("█") != 2 {
+		t.Fatal("DefaultCondition did not switch to East Asian widths")
+	}
+	if got := draw(); !bytes.Equal(got, want) {
+		t.Errorf("logo layout changed with runewidth.DefaultCondition.EastAsianWidth = true")
+	}
+}
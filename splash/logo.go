@@ -0,0 +1,55 @@
+package splash
+
+import "strings"
+
+var C = `◢████████◤◤    
+◢███◤     ◥███ 
+███            
+██             
+███            
+███            
+██             
+███           ◥
+ ███◤      ◢██ 
+   █████████◤  `
+
+var H = `██          
+██          
+██          
+███████████ 
+██       ██◤
+██       ███
+██       ███
+██       ███
+██       ███
+██       ███`
+
+var A = `██████████  
+        ███ 
+◢█████████  
+◢██◤      ██
+██        ██
+██       ███
+◥█████████◤ `
+var R = `██      
+████████
+██◤     
+██      
+██      
+██      
+██      
+██      `
+
+var M = ` ██  ______   ______ 
+◥██████████◤█████████
+◥██       ██       ██
+◥██       ██       ██
+◥██       ██       ██
+◥██       ██       ██
+◥██       ██       ██
+ ██       ██       ██`
+
+// splitIntoLines は与えられた文字列を行に分割する関数です。
+func splitIntoLines(s string) []string {
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,55 @@
+package splash
+
+// rasterizeSegment は v1 から v2 への線分が通るマスを Bresenham の方法で求めます。
+// 傾きはどんな値でも構いません。v1 は含みますが v2 は含まないので、
+// 線分をつなげたときにつなぎ目の頂点が二重になりません。v1 と v2 が同じなら空を返します。
+func rasterizeSegment(v1, v2 Vertex) []Vertex {
+	dx, dy := abs(v2.X-v1.X), -abs(v2.Y-v1.Y)
+	stepX, stepY := sign(v2.X-v1.X), sign(v2.Y-v1.Y)
+
+	result := make([]Vertex, 0, max(dx, -dy))
+	p := Vertex{X: v1.X, Y: v1.Y}
+	err := dx + dy
+	for p.X != v2.X || p.Y != v2.Y {
+		result = append(result, p)
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			p.X += stepX
+		}
+		if e2 <= dx {
+			err += dx
+			p.Y += stepY
+		}
+	}
+	return result
+}
+
+// rasterizePath は折れ線 line が通るマスを始点から順に返します。
+// 曲線は flattenPath で細かい直線に直してから、各線分を rasterizeSegment の規則で並べるので、折れ線の終点も含みません。
+// 既定の線は画面の上端と下端の行で終わっており、その行は空けておくためです。
+func rasterizePath(line []Vertex) []Vertex {
+	line = flattenPath(line)
+	points := make([]Vertex, 0)
+	for i := 0; i < len(line)-1; i++ {
+		points = append(points, rasterizeSegment(line[i], line[i+1])...)
+	}
+	return points
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func sign(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+package splash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRasterizeSegment(t *testing.T) {
+	tests := []struct {
+		name   string
+		v1, v2 Vertex
+		want   []Vertex
+	}{
+		{"same point", Vertex{X: 3, Y: 3}, Vertex{X: 3, Y: 3}, []Vertex{}},
+		{"horizontal", Vertex{X: 0, Y: 0}, Vertex{X: 3, Y: 0}, []Vertex{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
+		{"diagonal", Vertex{X: 2, Y: 2}, Vertex{X: 0, Y: 0}, []Vertex{{X: 2, Y: 2}, {X: 1, Y: 1}}},
+		{"shallow", Vertex{X: 0, Y: 0}, Vertex{X: 4, Y: 2}, []Vertex{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 2}}},
+		{"steep", Vertex{X: 0, Y: 0}, Vertex{X: -1, Y: -3}, []Vertex{{X: 0, Y: 0}, {X: 0, Y: -1}, {X: -1, Y: -2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rasterizeSegment(tt.v1, tt.v2); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRasterizePathIsConnected(t *testing.T) {
+	line := []Vertex{{X: 82, Y: 15}, {X: 70, Y: 11}, {X: 65, Y: 0}, {X: 40, Y: 34}}
+	points := rasterizePath(line)
+	if points[0] != line[0] {
+		t.Fatalf("path starts at %v, want %v", points[0], line[0])
+	}
+	// 隣り合うマスは8近傍でつながり、同じマスが続くことはない
+	for i := 1; i < len(points); i++ {
+		dx, dy := abs(points[i].X-points[i-1].X), abs(points[i].Y-points[i-1].Y)
+		if max(dx, dy) != 1 {
+			t.Errorf("points %v and %v are not adjacent", points[i-1], points[i])
+		}
+	}
+	last := line[len(line)-1]
+	if end := points[len(points)-1]; max(abs(end.X-last.X), abs(end.Y-last.Y)) != 1 {
+		t.Errorf("path ends at %v, want next to %v", end, last)
+	}
+}
@@ -0,0 +1,122 @@
+package splash
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mergeGap は変化したマス同士の間にある変化していないマスの数がこれ以下なら、
+// カーソルを動かさずにまとめて書き直します。カーソル移動のシーケンスより短く済むためです。
+const mergeGap = 4
+
+// DiffRenderer は前回のフレームを覚えておき、変化したマスだけを端末に送るレンダラーです。
+// 遅い SSH 接続や tmux の中でも、毎フレーム全画面を送り直さずに済みます。
+type DiffRenderer struct {
+	prev *Canvas
+}
+
+// NewDiffRenderer は何も描画していない状態のレンダラーを作ります。
+func NewDiffRenderer() *DiffRenderer {
+	return &DiffRenderer{}
+}
+
+// Reset は前回のフレームを忘れ、次の Render で画面全体を描き直させます。
+func (r *DiffRenderer) Reset() {
+	r.prev = nil
+}
+
+// Render は前回のフレームから c に更新するためのエスケープシーケンスを返します。
+// 初回や大きさが変わったときは画面を消してから全体を描きます。
+func (r *DiffRenderer) Render(c *Canvas) string {
+	b := strings.Builder{}
+	full := r.prev == nil || r.prev.Width() != c.Width() || r.prev.Height() != c.Height()
+	if full {
+		b.WriteString("\x1b[0m\x1b[2J")
+	}
+
+	pen := ""
+	for y := 0; y < c.Height(); y++ {
+		row := c.Row(y)
+		var prev []Cell
+		if !full {
+			prev = r.prev.Row(y)
+		}
+		for _, span := range changedSpans(prev, row) {
+			// 端末の座標は1から始まります
+			fmt.Fprintf(&b, "\x1b[%d;%dH", y+1, span.start+1)
+			for _, cell := range row[span.start:span.end] {
+				if style := sgr(cell); style != pen {
+					b.WriteString(style)
+					pen = style
+				}
+				b.WriteRune(cell.Rune)
+			}
+		}
+	}
+	if pen != "" {
+		b.WriteString("\x1b[0m")
+	}
+
+	r.prev = c.Clone()
+	return b.String()
+}
+
+// span は行の中で書き直す範囲 [start, end) です。
+type span struct {
+	start, end int
+}
+
+// changedSpans は prev から row に変わったマスの範囲を返します。prev が nil なら行全体を返します。
+// 間にある変化していないマスが mergeGap 以下の範囲はひとつにまとめます。
+func changedSpans(prev, row []Cell) []span {
+	if prev == nil {
+		if len(row) == 0 {
+			return nil
+		}
+		return []span{{0, len(row)}}
+	}
+	var spans []span
+	for x := 0; x < len(row); x++ {
+		if row[x] == prev[x] {
+			continue
+		}
+		if n := len(spans); n > 0 && x-spans[n-1].end <= mergeGap {
+			spans[n-1].end = x + 1
+			continue
+		}
+		spans = append(spans, span{x, x + 1})
+	}
+	return spans
+}
+
+// sgr はマスの色と装飾を設定するエスケープシーケンスを返します。
+// 直前のマスの装飾を引き継がないよう、毎回リセットしてから設定します。
+func sgr(cell Cell) string {
+	params := []string{"0"}
+	if cell.FG != nil {
+		if seq := cell.FG.Sequence(false); seq != "" {
+			params = append(params, seq)
+		}
+	}
+	if cell.BG != nil {
+		if seq := cell.BG.Sequence(true); seq != "" {
+			params = append(params, seq)
+		}
+	}
+	attrs := []struct {
+		attr Attrs
+		code string
+	}{
+		{Bold, "1"},
+		{Faint, "2"},
+		{Italic, "3"},
+		{Underline, "4"},
+		{Reverse, "7"},
+	}
+	for _, a := range attrs {
+		if cell.Attrs&a.attr != 0 {
+			params = append(params, a.code)
+		}
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
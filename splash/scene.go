@@ -0,0 +1,385 @@
+package splash
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Palette はシーンで使う色をまとめた構造体です。
+type Palette struct {
+	Canvas         string    // Canvas は最初のフレームの背景色です。
+	Text           string    // Text はまだ色の付いていない文字の色です。
+	Background     string    // Background は線の後ろの背景色です。
+	LogoBackground string    // LogoBackground はロゴの後ろの背景色です。
+	Core           string    // Core は中央のコアの色です。
+	Point          string    // Point は線の上を走る光の色です。
+	Line           [2]string // Line は線の始点から終点へのグラデーションです。
+	Logo           [2]string // Logo はロゴの左端から右端へのグラデーションです。
+	Sweep          [4]string // Sweep は横線を流れる色の並びです。
+}
+
+// DefaultPalette は従来のイントロと同じ色を返します。
+func DefaultPalette() Palette {
+	return Palette{
+		Canvas:         "#696969",
+		Text:           "#FFFFFF",
+		Background:     "#252525",
+		LogoBackground: "#FF99CC",
+		Core:           "#7FFF7F",
+		Point:          "#00ff7f",
+		Line:           [2]string{"#00ff7f", "#a8a8ff"},
+		Logo:           [2]string{"#8eff8e", "#7fffff"},
+		Sweep:          [4]string{"#ffff74", "#7fff7f", "#7fbfff", "#252525"},
+	}
+}
+
+// Scene はイントロ全体の構成を表す構造体です。
+type Scene struct {
+	Palette    Palette
+	Timeline   Timeline
+	LeftLines  [][]Vertex
+	RightLines [][]Vertex  // RightLines は Symmetry が nil でなければ UpdateLines で LeftLines から作ります。
+	Symmetry   *Symmetry   // Symmetry は左右の線の対称性です。nil なら RightLines をそのまま使います。
+	Text       string      // Text はロゴに書く文字列です。
+	Font       *FIGletFont // Font が nil でなければ、ロゴをこの FIGlet フォントで書きます。
+	Spacing    int         // Spacing は組み込みのブロック文字で書くときの字間です。
+	Logo       string      // Logo は中央に表示するロゴの絵です。UpdateLogo で Text から作ります。
+}
+
+// DefaultScene は組み込みの既定シーンを返します。
+func DefaultScene() *Scene {
+	scene := &Scene{
+		Text:      DefaultText,
+		Spacing:   DefaultSpacing,
+		Palette:   DefaultPalette(),
+		Timeline:  DefaultTimeline(),
+		LeftLines: leftLines,
+		Symmetry:  DefaultSymmetry(),
+	}
+	scene.UpdateLines()
+	// DefaultText の文字には全て字形があるので失敗しません
+	_ = scene.UpdateLogo()
+	return scene
+}
+
+// UpdateLogo は Text を Font か組み込みのブロック文字で書き、Logo を作り直します。
+func (s *Scene) UpdateLogo() error {
+	var logo string
+	var err error
+	if s.Font != nil {
+		logo, err = s.Font.Render(s.Text)
+	} else {
+		logo, err = TextLayout{Spacing: s.Spacing}.Layout(s.Text)
+	}
+	if err != nil {
+		return err
+	}
+	s.Logo = logo
+	return nil
+}
+
+// UpdateLines は Symmetry に従って LeftLines を写し、RightLines を作り直します。
+// Symmetry が nil なら何もしません。
+func (s *Scene) UpdateLines() {
+	if s.Symmetry != nil {
+		s.RightLines = s.Symmetry.Apply(s.LeftLines)
+	}
+}
+
+// sceneFile はシーンファイルの書式です。省略した項目には既定値が使われます。
+type sceneFile struct {
+	Text    *string     `json:"text" yaml:"text"`
+	Font    string      `json:"font" yaml:"font"`
+	Spacing *int        `json:"spacing" yaml:"spacing"`
+	Palette paletteFile `json:"palette" yaml:"palette"`
+	Assets  assetsFile  `json:"assets" yaml:"assets"`
+	Phases  []phaseFile `json:"phases" yaml:"phases"`
+}
+
+type paletteFile struct {
+	Canvas         *string  `json:"canvas" yaml:"canvas"`
+	Text           *string  `json:"text" yaml:"text"`
+	Background     *string  `json:"background" yaml:"background"`
+	LogoBackground *string  `json:"logoBackground" yaml:"logoBackground"`
+	Core           *string  `json:"core" yaml:"core"`
+	Point          *string  `json:"point" yaml:"point"`
+	Line           []string `json:"line" yaml:"line"`
+	Logo           []string `json:"logo" yaml:"logo"`
+	Sweep          []string `json:"sweep" yaml:"sweep"`
+}
+
+type assetsFile struct {
+	LeftLines  string        `json:"leftLines" yaml:"leftLines"`
+	RightLines string        `json:"rightLines" yaml:"rightLines"`
+	Symmetry   *symmetryFile `json:"symmetry" yaml:"symmetry"`
+}
+
+type symmetryFile struct {
+	Type string   `json:"type" yaml:"type"`
+	X    *float64 `json:"x" yaml:"x"`
+	Y    *float64 `json:"y" yaml:"y"`
+}
+
+type phaseFile struct {
+	Name     string   `json:"name" yaml:"name"`
+	Duration *float64 `json:"duration" yaml:"duration"` // 秒
+	Easing   *string  `json:"easing" yaml:"easing"`
+}
+
+// LoadScene はJSONまたはYAMLのシーンファイルを読み込みます。
+// 書式の誤りはどの項目が原因かが分かるエラーとして返します。
+func LoadScene(path string) (*Scene, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading scene file: %w", err)
+	}
+
+	var file sceneFile
+	if err := decodeSceneFile(path, data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	scene, err := file.build(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scene, nil
+}
+
+func decodeSceneFile(path string, data []byte, file *sceneFile) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(file); err != nil {
+			return fmt.Errorf("error unmarshalling JSON: %w", err)
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(file); err != nil {
+			return fmt.Errorf("error unmarshalling YAML: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported scene file extension %q (want .json, .yaml or .yml)", filepath.Ext(path))
+	}
+	return nil
+}
+
+// build はシーンファイルの内容を検証し、既定値で補ったシーンを組み立てます。
+// アセットのパスは dir からの相対パスとして扱います。
+func (f *sceneFile) build(dir string) (*Scene, error) {
+	scene := DefaultScene()
+
+	if f.Text != nil {
+		scene.Text = *f.Text
+	}
+	if f.Spacing != nil {
+		if *f.Spacing < MinSpacing || *f.Spacing > MaxSpacing {
+			return nil, fmt.Errorf("spacing: must be between %d and %d, got %d", MinSpacing, MaxSpacing, *f.Spacing)
+		}
+		scene.Spacing = *f.Spacing
+	}
+	if f.Font != "" {
+		font, err := LoadFIGletFont(resolvePath(dir, f.Font))
+		if err != nil {
+			return nil, fmt.Errorf("font: %w", err)
+		}
+		scene.Font = font
+	}
+	if err := scene.UpdateLogo(); err != nil {
+		return nil, fmt.Errorf("text: %w", err)
+	}
+
+	if err := f.Palette.apply(&scene.Palette); err != nil {
+		return nil, err
+	}
+
+	if f.Phases != nil {
+		timeline, err := buildTimeline(f.Phases)
+		if err != nil {
+			return nil, err
+		}
+		scene.Timeline = timeline
+	}
+
+	if f.Assets.LeftLines != "" {
+		lines, err := ReadLines(resolvePath(dir, f.Assets.LeftLines))
+		if err != nil {
+			return nil, fmt.Errorf("assets.leftLines: %w", err)
+		}
+		scene.LeftLines = lines
+	}
+	if f.Assets.RightLines != "" {
+		if f.Assets.Symmetry != nil {
+			return nil, fmt.Errorf("assets.rightLines: cannot be used together with assets.symmetry")
+		}
+		lines, err := ReadLines(resolvePath(dir, f.Assets.RightLines))
+		if err != nil {
+			return nil, fmt.Errorf("assets.rightLines: %w", err)
+		}
+		scene.RightLines = lines
+		scene.Symmetry = nil
+	}
+	if f.Assets.Symmetry != nil {
+		symmetry, err := f.Assets.Symmetry.build()
+		if err != nil {
+			return nil, fmt.Errorf("assets.symmetry: %w", err)
+		}
+		scene.Symmetry = symmetry
+	}
+	scene.UpdateLines()
+
+	return scene, nil
+}
+
+func (f *symmetryFile) build() (*Symmetry, error) {
+	kind, ok := ParseSymmetryKind(f.Type)
+	if !ok {
+		return nil, fmt.Errorf("type: unknown symmetry %q (want one of %s)", f.Type, strings.Join(symmetryNames(), ", "))
+	}
+	symmetry := DefaultSymmetry()
+	symmetry.Kind = kind
+	if f.X != nil {
+		symmetry.X = *f.X
+	}
+	if f.Y != nil {
+		symmetry.Y = *f.Y
+	}
+	return symmetry, nil
+}
+
+func (f *paletteFile) apply(p *Palette) error {
+	colors := []struct {
+		field string
+		src   *string
+		dst   *string
+	}{
+		{"palette.canvas", f.Canvas, &p.Canvas},
+		{"palette.text", f.Text, &p.Text},
+		{"palette.background", f.Background, &p.Background},
+		{"palette.logoBackground", f.LogoBackground, &p.LogoBackground},
+		{"palette.core", f.Core, &p.Core},
+		{"palette.point", f.Point, &p.Point},
+	}
+	for _, c := range colors {
+		if c.src == nil {
+			continue
+		}
+		if _, err := parseColor(*c.src); err != nil {
+			return fmt.Errorf("%s: %q: %w", c.field, *c.src, err)
+		}
+		*c.dst = *c.src
+	}
+
+	if err := applyGradient("palette.line", f.Line, p.Line[:]); err != nil {
+		return err
+	}
+	if err := applyGradient("palette.logo", f.Logo, p.Logo[:]); err != nil {
+		return err
+	}
+	return applyGradient("palette.sweep", f.Sweep, p.Sweep[:])
+}
+
+// applyGradient は src の色を dst にコピーします。色の数は dst と同じでなければなりません。
+func applyGradient(field string, src, dst []string) error {
+	if src == nil {
+		return nil
+	}
+	if len(src) != len(dst) {
+		return fmt.Errorf("%s: want %d colors, got %d", field, len(dst), len(src))
+	}
+	for i, color := range src {
+		if _, err := parseColor(color); err != nil {
+			return fmt.Errorf("%s[%d]: %q: %w", field, i, color, err)
+		}
+	}
+	copy(dst, src)
+	return nil
+}
+
+func buildTimeline(phases []phaseFile) (Timeline, error) {
+	if len(phases) == 0 {
+		return nil, fmt.Errorf("phases: at least one phase is required")
+	}
+	timeline := make(Timeline, 0, len(phases))
+	for i, p := range phases {
+		t, ok := ParseAnimationType(p.Name)
+		if !ok {
+			return nil, fmt.Errorf("phases[%d].name: unknown phase %q (want one of %s)", i, p.Name, strings.Join(phaseNames(), ", "))
+		}
+		phase := BuiltinPhase(t)
+		if p.Duration != nil {
+			seconds := *p.Duration
+			if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+				return nil, fmt.Errorf("phases[%d].duration: must be a finite number of seconds, got %g", i, seconds)
+			}
+			if seconds*float64(time.Second) >= math.MaxInt64 {
+				return nil, fmt.Errorf("phases[%d].duration: must be shorter than %v, got %gs", i, time.Duration(math.MaxInt64), seconds)
+			}
+			// 秒数を先に変換して調べる。1ns に満たない長さは 0 になってしまう
+			phase.Duration = time.Duration(seconds * float64(time.Second))
+			if phase.Duration <= 0 {
+				return nil, fmt.Errorf("phases[%d].duration: must be at least 1ns, got %gs", i, seconds)
+			}
+		}
+		if p.Easing != nil {
+			easing, ok := easings[*p.Easing]
+			if !ok {
+				return nil, fmt.Errorf("phases[%d].easing: unknown easing %q (want one of %s)", i, *p.Easing, strings.Join(easingNames(), ", "))
+			}
+			phase.Easing = easing
+		}
+		timeline = append(timeline, phase)
+	}
+	return timeline, nil
+}
+
+func phaseNames() []string {
+	names := make([]string, 0, len(animationTypeNames))
+	for t := Dark; t <= Loopback; t++ {
+		names = append(names, t.String())
+	}
+	return names
+}
+
+func symmetryNames() []string {
+	names := make([]string, 0, len(symmetryKindNames))
+	for k := SymmetryHorizontal; k <= SymmetryRotational; k++ {
+		names = append(names, k.String())
+	}
+	return names
+}
+
+func easingNames() []string {
+	names := make([]string, 0, len(easings))
+	for name := range easings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadLines は線のファイルを読みます。拡張子が .svg なら SVG の線をデザインの大きさのマス目に合わせて読み込みます。
+func ReadLines(path string) ([][]Vertex, error) {
+	if strings.ToLower(filepath.Ext(path)) == ".svg" {
+		return LoadSVG(path, DesignWidth, DesignHeight)
+	}
+	return readVertex(path)
+}
+
+func resolvePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
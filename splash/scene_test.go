@@ -0,0 +1,39 @@
+package splash
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestBuildTimelineRejectsBadDurations(t *testing.T) {
+	for _, seconds := range []float64{0, -1, 1e-10, math.NaN(), math.Inf(1), 1e300} {
+		seconds := seconds
+		_, err := buildTimeline([]phaseFile{{Name: "Dark", Duration: &seconds}})
+		if err == nil || !strings.Contains(err.Error(), "phases[0].duration") {
+			t.Errorf("duration %g: got %v, want a phases[0].duration error", seconds, err)
+		}
+	}
+}
+
+func TestAdvanceZeroLengthTimeline(t *testing.T) {
+	m := NewSlideModel(DefaultScene())
+	m.Timeline = Timeline{BuiltinPhase(Dark)}
+	m.Timeline[0].Duration = 0
+	// 長さのないタイムラインでも0除算で止まらず、再生位置もそのまま
+	m.Advance(FrameInterval)
+	if m.Phase != 0 || m.Ratio != 0 {
+		t.Errorf("got phase %d ratio %g, want phase 0 ratio 0", m.Phase, m.Ratio)
+	}
+}
+
+func TestBuildRejectsSpacingOutOfRange(t *testing.T) {
+	for _, spacing := range []int{MinSpacing - 1, MaxSpacing + 1} {
+		spacing := spacing
+		file := sceneFile{Spacing: &spacing}
+		_, err := file.build(".")
+		if err == nil || !strings.HasPrefix(err.Error(), "spacing:") {
+			t.Errorf("spacing %d: got %v, want a spacing error", spacing, err)
+		}
+	}
+}
@@ -0,0 +1,752 @@
+package splash
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muesli/termenv"
+)
+
+const leftCore = ` ▄▄
+██ 
+ ▀▀`
+const rightCore = `▄▄ 
+ ██
+▀▀ `
+
+// 線を頂点で持つ
+type Vertex struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	// Curve が nil でなければ、直前の頂点からこの頂点までを直線ではなく曲線でつなぎます。
+	Curve *Curve `json:"curve,omitempty"`
+}
+
+func readVertex(jsonPath string) ([][]Vertex, error) {
+	byteValue, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading JSON file: %w", err)
+	}
+	return parseVertex(byteValue)
+}
+
+func parseVertex(data []byte) ([][]Vertex, error) {
+	var vertices [][]Vertex
+	if err := json.Unmarshal(data, &vertices); err != nil {
+		return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+	if err := validatePaths(vertices); err != nil {
+		return nil, err
+	}
+
+	return vertices, nil
+}
+
+// leftLineJSON は既定の左側の線です。右側の線は DefaultSymmetry で写して作ります。
+//
+//go:embed assets/leftLine.json
+var leftLineJSON []byte
+
+var leftLines = mustParseVertex(leftLineJSON)
+
+// mustParseVertex は埋め込んだ線を読みます。埋め込んだファイルが壊れているのはビルドの誤りなので panic します。
+func mustParseVertex(data []byte) [][]Vertex {
+	lines, err := parseVertex(data)
+	if err != nil {
+		panic(fmt.Sprintf("splash: embedded leftLine.json: %v", err))
+	}
+	return lines
+}
+
+// DesignWidth と DesignHeight は頂点データやロゴを描いたときの画面の大きさです。
+// 実際の端末がこれと異なる場合は中央に寄せて描画します。
+const DesignWidth = 170
+const DesignHeight = 35
+
+// slideModel はスライドのモデルを表す構造体です。
+type SlideModel struct {
+	Timeline   Timeline // Timeline は再生するフェーズの並びです。
+	Phase      int      // Phase は再生中のフェーズの Timeline 上の位置です。
+	Ratio      float64  // Ratio は比率を表すfloat型です。
+	Paused     bool     // Paused が真の間は Update しても再生位置が進みません。
+	Speed      float64  // Speed は再生速度の倍率です。
+	Reverse    bool     // Reverse が真なら Update で逆向きに再生します。
+	ratio      float64
+	elapsed    time.Duration
+	clock      Clock
+	last       time.Time // last は前回 Update したときの時刻です。
+	loops      int
+	progress   *ProgressMsg // progress が nil でなければ読み込みの終わりを待ちます。
+	palette    Palette
+	leftLines  [][]Vertex
+	rightLines [][]Vertex
+	logo       string
+	width      int
+	height     int
+	canvas     *Canvas
+}
+
+// NewSlideModel は scene を最初から再生する SlideModel を作ります。
+// scene の Timeline が空なら DefaultTimeline を再生します。
+func NewSlideModel(scene *Scene) *SlideModel {
+	timeline := scene.Timeline
+	if len(timeline) == 0 {
+		timeline = DefaultTimeline()
+	}
+	m := &SlideModel{
+		Timeline:   timeline,
+		palette:    scene.Palette,
+		leftLines:  scene.LeftLines,
+		rightLines: scene.RightLines,
+		logo:       scene.Logo,
+		Ratio:      0.0,
+		ratio:      0.0,
+		Speed:      1,
+	}
+	m.SetClock(RealClock())
+	m.Resize(DesignWidth, DesignHeight)
+	return m
+}
+
+// Resize は画面の大きさを変更し、描画内容を初期状態に戻します。
+func (m *SlideModel) Resize(width, height int) {
+	if width < 0 {
+		width = 0
+	}
+	if height < 0 {
+		height = 0
+	}
+	m.width = width
+	m.height = height
+	m.canvas = NewCanvas(width, height, Cell{
+		Rune: ' ',
+		FG:   termenv.TrueColor.Color(m.palette.Text),
+		BG:   termenv.TrueColor.Color(m.palette.Canvas),
+	})
+}
+
+// Canvas は最後に描画した画面を返します。
+func (m *SlideModel) Canvas() *Canvas {
+	return m.canvas
+}
+
+// SetClock は Update が参照する時計を c に替えます。再生位置は変わりません。
+func (m *SlideModel) SetClock(c Clock) {
+	m.clock = c
+	m.last = c.Now()
+}
+
+// Update は前回の Update から時計が進んだ分に Speed を掛けた時間だけ再生位置を進めます。
+// Paused の間は進めず、Reverse なら戻します。
+func (m *SlideModel) Update() *SlideModel {
+	now := m.clock.Now()
+	dt := time.Duration(float64(now.Sub(m.last)) * m.Speed)
+	m.last = now
+	if m.Paused {
+		return m
+	}
+	if m.Reverse {
+		dt = -dt
+	}
+	m.Advance(dt)
+	return m
+}
+
+// Advance は再生位置を dt だけ進めます。dt が負なら戻します。
+// フェーズの長さを超えた分は隣のフェーズに持ち越すので、描画の間隔が変わってもアニメーション全体の長さは変わりません。
+func (m *SlideModel) Advance(dt time.Duration) {
+	// 長さのないタイムラインは進めようがない
+	total := m.Timeline.Length()
+	if total <= 0 {
+		return
+	}
+	m.elapsed += dt
+	// タイムライン何周分も進む場合はまとめて読み飛ばす。読み込みを待つフェーズは飛ばせないので1つずつ進める
+	if !m.waiting() {
+		m.loops += int(m.elapsed / total)
+		m.elapsed %= total
+	}
+	for m.elapsed < 0 {
+		if m.Phase == 0 {
+			m.loops--
+		}
+		m.Phase = (m.Phase - 1 + len(m.Timeline)) % len(m.Timeline)
+		m.elapsed += m.current().Duration
+	}
+	for m.elapsed >= m.current().Duration {
+		if m.holds(m.Phase) && m.waiting() {
+			m.elapsed = m.current().Duration - 1
+			break
+		}
+		m.elapsed -= m.current().Duration
+		m.Phase = (m.Phase + 1) % len(m.Timeline)
+		if m.Phase == 0 {
+			m.loops++
+		}
+	}
+	m.Ratio = float64(m.elapsed) / float64(m.current().Duration)
+	m.ratio = m.current().Easing(m.Ratio)
+}
+
+// Loops はタイムラインの最後から先頭に戻った回数を返します。逆向きに先頭から最後へ戻ると減ります。
+func (m *SlideModel) Loops() int {
+	return m.loops
+}
+
+// SetProgress は読み込みの進み具合を ratio (0..1) に、ロゴの下に表示する状況を status にします。
+// 一度呼ぶと、Hold のフェーズは ratio が 1 になるまで終わりで止まり、ロゴの色も ratio の位置までしか塗りません。
+// Hold のフェーズがなければ最後のフェーズの終わりで止まります。
+func (m *SlideModel) SetProgress(ratio float64, status string) {
+	m.progress = &ProgressMsg{Ratio: clamp(ratio), Status: status}
+}
+
+// holds は Timeline の phase 番目のフェーズが読み込みの終わりを待つフェーズかを返します。
+// Hold のフェーズがないタイムラインでは、一周を終えて引き継ぐ前に待てるよう最後のフェーズの終わりで待ちます。
+func (m *SlideModel) holds(phase int) bool {
+	if m.Timeline[phase].Hold {
+		return true
+	}
+	if phase != len(m.Timeline)-1 {
+		return false
+	}
+	for _, p := range m.Timeline {
+		if p.Hold {
+			return false
+		}
+	}
+	return true
+}
+
+// waiting は読み込みの終わりを待っているかを返します。
+func (m *SlideModel) waiting() bool {
+	return m.progress != nil && m.progress.Ratio < 1
+}
+
+// Seek は Timeline の phase 番目のフェーズの ratio の位置に移動します。
+func (m *SlideModel) Seek(phase int, ratio float64) {
+	m.Phase = phase
+	m.elapsed = time.Duration(ratio * float64(m.current().Duration))
+	m.Ratio = ratio
+	m.ratio = m.current().Easing(m.Ratio)
+}
+
+// SeekTime はタイムラインの最初から t 経った位置に移動します。タイムラインの長さを超えた分は先頭に戻ります。
+func (m *SlideModel) SeekTime(t time.Duration) {
+	m.Seek(0, 0)
+	m.Advance(t)
+}
+
+// SeekFrame はタイムラインの最初から frame 枚目のフレームの瞬間まで進めます。
+func (m *SlideModel) SeekFrame(frame int) {
+	m.SeekTime(time.Duration(frame) * FrameInterval)
+}
+
+// PhaseIndex は name という名前のフェーズが Timeline の何番目にあるかを返します。見つからなければ -1 を返します。
+func (m *SlideModel) PhaseIndex(name string) int {
+	for i, p := range m.Timeline {
+		if p.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// current は再生中のフェーズを返します。
+func (m *SlideModel) current() Phase {
+	return m.Timeline[m.Phase]
+}
+
+// CurrentPhase は再生中のフェーズを返します。
+func (m *SlideModel) CurrentPhase() Phase {
+	return m.current()
+}
+
+// Palette は描画に使っている色の組を返します。
+func (m *SlideModel) Palette() Palette {
+	return m.palette
+}
+
+// artOrigin は頂点データの座標を画面の座標に移すためのずらし幅を返します。
+func (m *SlideModel) artOrigin() (int, int) {
+	return (m.width - DesignWidth) / 2, (m.height - DesignHeight) / 2
+}
+
+func (m *SlideModel) clearAll() {
+	m.canvas.FillRune(' ')
+}
+func (m *SlideModel) clearLeft(border int) {
+	m.canvas.Sub(Rect{Width: border + 1, Height: m.height}).FillRune(' ')
+}
+func (m *SlideModel) clearRight(border int) {
+	m.canvas.Sub(Rect{X: border, Width: m.width - border, Height: m.height}).FillRune(' ')
+}
+
+func (m *SlideModel) setLeftBackground(border int, color string) {
+	m.canvas.Sub(Rect{Width: border + 1, Height: m.height}).FillBG(termenv.TrueColor.Color(color))
+}
+func (m *SlideModel) setRightBackground(border int, color string) {
+	m.canvas.Sub(Rect{X: border, Width: m.width - border, Height: m.height}).FillBG(termenv.TrueColor.Color(color))
+}
+
+func (m *SlideModel) renderLines(ratio float64) {
+	offset := int(math.Round(float64(m.width) / 2 * ratio))
+	dx, dy := m.artOrigin()
+	m.clearLeft(m.width/2 - offset)
+	m.setLeftBackground(m.width/2-offset-1, m.palette.Background)
+	for _, line := range m.leftLines {
+		for _, p := range rasterizePath(line) {
+			m.canvas.SetRune(p.X+dx-offset, p.Y+dy, '█')
+		}
+	}
+	m.clearRight(m.width/2 + 1 + offset)
+	m.setRightBackground(m.width/2-1+offset, m.palette.Background)
+	for _, line := range m.rightLines {
+		for _, p := range rasterizePath(line) {
+			m.canvas.SetRune(p.X+dx+offset, p.Y+dy, '█')
+		}
+	}
+}
+
+func (m *SlideModel) renderLineColor(ratio float64) {
+	m.renderLineColorWithOffset(ratio, 0)
+}
+func (m *SlideModel) renderLineColorWithOffset(ratio, offsetRatio float64) {
+	offset := int(math.Round(float64(m.width) / 2 * offsetRatio))
+	m.canvas.FillFG(termenv.TrueColor.Color(m.palette.Text))
+	for _, line := range m.leftLines {
+		m.changeStyleLine(line, ratio, -offset)
+	}
+	for _, line := range m.rightLines {
+		m.changeStyleLine(line, ratio, offset)
+	}
+}
+
+func (m *SlideModel) renderPointColor(ratio float64) {
+	m.canvas.FillFG(termenv.TrueColor.Color(m.palette.Text))
+	for _, line := range m.leftLines {
+		m.changeStyleAtPoint(line, ratio)
+	}
+	for _, line := range m.rightLines {
+		m.changeStyleAtPoint(line, ratio)
+	}
+}
+
+func (m *SlideModel) changeStyleAtPoint(line []Vertex, ratio float64) {
+	linePoints := rasterizePath(line)
+	index := int(float64(len(linePoints)) * ratio)
+	if index >= len(linePoints) {
+		return
+	}
+	target := linePoints[index]
+	dx, dy := m.artOrigin()
+	m.canvas.SetFG(target.X+dx, target.Y+dy, termenv.TrueColor.Color(m.palette.Point))
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+func lerpColor(startColor, endColor [3]int, t float64) string {
+	red := int(lerp(float64(startColor[0]), float64(endColor[0]), t))
+	green := int(lerp(float64(startColor[1]), float64(endColor[1]), t))
+	blue := int(lerp(float64(startColor[2]), float64(endColor[2]), t))
+
+	return fmt.Sprintf("#%02x%02x%02x", red, green, blue)
+}
+
+// parseColor は文字列形式のカラーコードを解析し、RGB値を表すintのスライスを返します。
+func parseColor(hexColor string) ([3]int, error) {
+	if len(hexColor) != 7 || hexColor[0] != '#' {
+		return [3]int{}, fmt.Errorf("invalid color format")
+	}
+	red, err := strconv.ParseInt(hexColor[1:3], 16, 64)
+	if err != nil {
+		return [3]int{}, fmt.Errorf("error parsing red component: %v", err)
+	}
+	green, err := strconv.ParseInt(hexColor[3:5], 16, 64)
+	if err != nil {
+		return [3]int{}, fmt.Errorf("error parsing green component: %v", err)
+	}
+	blue, err := strconv.ParseInt(hexColor[5:7], 16, 64)
+	if err != nil {
+		return [3]int{}, fmt.Errorf("error parsing blue component: %v", err)
+	}
+	return [3]int{int(red), int(green), int(blue)}, nil
+}
+
+func (m *SlideModel) changeStyleLine(line []Vertex, ratio float64, offset int) {
+	linePoints := rasterizePath(line)
+	if len(linePoints) == 0 {
+		return
+	}
+	index := int(float64(len(linePoints)) * ratio)
+	if index >= len(linePoints) {
+		index = len(linePoints) - 1
+	}
+	startColor, _ := parseColor(m.palette.Line[0])
+	endColor, _ := parseColor(m.palette.Line[1])
+	dx, dy := m.artOrigin()
+
+	for i := 0; i <= index; i++ {
+		target := linePoints[i]
+		t := float64(i) / float64(len(linePoints)-1)
+		color := lerpColor(startColor, endColor, t)
+		m.canvas.SetFG(target.X+dx+offset, target.Y+dy, termenv.TrueColor.Color(color))
+	}
+}
+
+func (m *SlideModel) renderCore(core string, offset, startColumn, startRow int) {
+	m.canvas.Blit(TextCanvas(core), startColumn+offset, startRow)
+}
+
+func (m *SlideModel) renderCoreColor(core, color string, offset, startColumn, startRow int) {
+	text := TextCanvas(core)
+	m.canvas.Sub(Rect{X: startColumn + offset, Y: startRow, Width: text.Width(), Height: text.Height()}).FillFG(termenv.TrueColor.Color(color))
+}
+
+func (m *SlideModel) renderCenter(ratio float64) {
+	offset := int(math.Round(float64(m.width) / 2 * ratio))
+	dx, dy := m.artOrigin()
+
+	m.renderCore(leftCore, -offset, 82+dx, DesignHeight/2-1+dy)
+
+	m.renderCore(rightCore, offset, 84+dx, DesignHeight/2-1+dy)
+}
+
+func (m *SlideModel) renderCenterColor(ratio float64) {
+	offset := int(math.Round(float64(m.width) / 2 * ratio))
+	dx, dy := m.artOrigin()
+
+	m.renderCoreColor(leftCore, m.palette.Core, -offset, 82+dx, DesignHeight/2-1+dy)
+
+	m.renderCoreColor(rightCore, m.palette.Core, offset, 84+dx, DesignHeight/2-1+dy)
+}
+
+// logoOrigin はロゴを画面の中央に置くときの左上の座標を返します。
+func (m *SlideModel) logoOrigin(logo string) (int, int) {
+	lines := strings.Split(logo, "\n")
+	logoWidth := 0
+	for _, line := range lines {
+		logoWidth = max(logoWidth, cellWidth.StringWidth(line))
+	}
+	return (m.width - logoWidth) / 2, (m.height - len(lines)) / 2
+}
+
+func (m *SlideModel) renderLogo() {
+	logo := m.logo
+	column, row := m.logoOrigin(logo)
+	m.renderCore(logo, 0, column, row)
+}
+
+// renderStatus は SetProgress で渡された状況をロゴの下に表示します。
+func (m *SlideModel) renderStatus() {
+	if m.progress == nil || m.progress.Status == "" {
+		return
+	}
+	logo := m.logo
+	_, row := m.logoOrigin(logo)
+	label := TextCanvas(m.progress.Status)
+	label.FillFG(termenv.TrueColor.Color(m.palette.Background))
+	m.canvas.Blit(label, (m.width-label.Width())/2, row+strings.Count(logo, "\n")+2)
+}
+
+func (m *SlideModel) renderLogoBackgroundColor() {
+	m.canvas.FillBG(termenv.TrueColor.Color(m.palette.LogoBackground))
+}
+
+func (m *SlideModel) renderLogoColor(ratio float64, color1, color2 string, colorOffset float64) {
+	logo := m.logo
+	lines := strings.Split(logo, "\n")
+	startColumn, startRow := m.logoOrigin(logo)
+
+	rgb1, _ := parseColor(color1)
+	rgb2, _ := parseColor(color2)
+
+	for i, line := range lines {
+		chars := strings.Split(line, "")
+		for j := range chars {
+			column := j + startColumn
+			row := i + startRow
+			colorRatio := float64(j) / float64((len(chars) - 1))
+			if colorRatio > ratio {
+				m.canvas.SetFG(column, row, termenv.TrueColor.Color(m.palette.Text))
+				continue
+			}
+			color := lerpColor(rgb1, rgb2, colorRatio)
+			m.canvas.SetFG(column, row, termenv.TrueColor.Color(color))
+		}
+	}
+}
+
+func clamp(v float64) float64 {
+	if v > 1 {
+		return 1
+	}
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// bandCount は横線を引く帯の数です。
+// 上下から3本ずつ、外側から順に折り返しながら引いていきます。
+const bandCount = 6
+
+// bandHeight は横線1本分の帯の高さを返します。
+func (m *SlideModel) bandHeight() int {
+	return max((m.height+bandCount-1)/bandCount, 1)
+}
+
+// band は上から index 番目の帯の行の範囲を返します。
+func (m *SlideModel) band(index int) (int, int) {
+	h := m.bandHeight()
+	return min(index*h, m.height), min((index+1)*h, m.height)
+}
+
+// renderBar は帯の start 行目から end 行目の from 列目から to 列目までに線の先端を描きます。
+// 帯が模様より高い場合は模様を繰り返します。
+func (m *SlideModel) renderBar(bars string, start, end, from, to int) {
+	pattern := TextCanvas(bars)
+	area := m.canvas.Sub(Rect{X: from, Y: start, Width: to - from, Height: end - start})
+	for y := 0; y < end-start; y += pattern.Height() {
+		area.Blit(pattern, 0, y)
+	}
+}
+
+func (m *SlideModel) renderHorizontalHeader(ratio float64) {
+	width1, width2, width3 := m.getHorizontalWidths(ratio)
+
+	barWidth := 10
+	for _, index := range []int{0, 5} {
+		start, end := m.band(index)
+		m.renderBar(barRight, start, end, width1, width1+barWidth)
+	}
+	for _, index := range []int{1, 4} {
+		start, end := m.band(index)
+		m.renderBar(barLeft, start, end, m.width-width2-barWidth, width1-width2)
+	}
+	if width3 == 0 {
+		return
+	}
+	for _, index := range []int{2, 3} {
+		start, end := m.band(index)
+		m.renderBar(barRight, start, end, width3, width3+barWidth)
+	}
+}
+
+func (m *SlideModel) renderHoritontalLine(ratio float64) {
+	// まずはそれぞれの線の比率を計算する
+	width1, width2, width3 := m.getHorizontalWidths(ratio)
+
+	// 一番外側の帯は左から右へ x=0,1,...width1
+	for _, index := range []int{0, 5} {
+		start, end := m.band(index)
+		m.canvas.Sub(Rect{Y: start, Width: width1, Height: end - start}).FillRune('█')
+	}
+
+	// 次の帯は右から左へ
+	for _, index := range []int{1, 4} {
+		start, end := m.band(index)
+		m.canvas.Sub(Rect{X: m.width - width2, Y: start, Width: width2, Height: end - start}).FillRune('█')
+	}
+
+	for _, index := range []int{2, 3} {
+		start, end := m.band(index)
+		m.canvas.Sub(Rect{Y: start, Width: width3, Height: end - start}).FillRune('█')
+	}
+}
+
+func (m *SlideModel) getHorizontalWidths(ratio float64) (int, int, int) {
+	var ratio1, ratio2, ratio3 = 0.0, 0.0, 0.0
+	if ratio > 0.33 {
+		ratio1 = 1.0
+	} else {
+		ratio1 = ratio * 3
+	}
+	if ratio <= 0.33 {
+		ratio2 = 0.0
+	} else if ratio > 0.66 {
+		ratio2 = 1.0
+	} else {
+		ratio2 = (ratio - 0.33) * 3
+	}
+	if ratio <= 0.66 {
+		ratio3 = 0.0
+	} else {
+		ratio3 = (ratio - 0.66) * 3
+	}
+	ratio1 = clamp(ratio1)
+	ratio2 = clamp(ratio2)
+	ratio3 = clamp(ratio3)
+	width1 := int(float64(m.width) * ratio1)
+	width2 := int(float64(m.width) * ratio2)
+	width3 := int(float64(m.width) * ratio3)
+	return width1, width2, width3
+}
+
+// getColorFromDistance は線の先頭からの距離に応じた色を返します。
+// span ごとに color1 から color4 へ順に移り変わります。
+func getColorFromDistance(distance, span int, color1, color2, color3, color4 string) string {
+	c1, _ := parseColor(color1)
+	c2, _ := parseColor(color2)
+	c3, _ := parseColor(color3)
+	c4, _ := parseColor(color4)
+	if distance < span {
+		return lerpColor(c1, c2, float64(distance)/float64(span))
+	} else if distance < 2*span {
+		return lerpColor(c2, c3, float64(distance-span)/float64(span))
+	} else if distance < 3*span {
+		return lerpColor(c3, c4, float64(distance-2*span)/float64(span))
+	} else {
+		return color4
+	}
+}
+
+// calcDistance は横線を引き始めた点から b までの線に沿った距離を返します。
+func (m *SlideModel) calcDistance(b Vertex) int {
+	b.Y = b.Y / m.bandHeight()
+	bSum := 0
+	if b.Y >= 3 {
+		b.Y %= 3
+		bSum += (2 - b.Y) * m.width
+		if b.Y%2 == 0 {
+			bSum += b.X
+		} else {
+			bSum += m.width - b.X - 1
+		}
+	} else {
+		b.Y %= 3
+		bSum += b.Y * m.width
+		if b.Y%2 == 0 {
+			bSum += b.X
+		} else {
+			bSum += m.width - b.X - 1
+		}
+	}
+	return bSum
+}
+
+func (m *SlideModel) calcHeadVertex(ratio float64) (Vertex, Vertex) {
+	if ratio < 0.33 {
+		w := int(float64(m.width) * ratio * 3)
+		upper, _ := m.band(0)
+		lower, _ := m.band(5)
+		return Vertex{
+				X: w,
+				Y: upper,
+			},
+			Vertex{
+				X: w,
+				Y: lower,
+			}
+	} else if ratio < 0.66 {
+		w := int(float64(m.width) * (ratio - 0.33) * 3)
+		upper, _ := m.band(1)
+		lower, _ := m.band(4)
+		return Vertex{
+				X: m.width - w,
+				Y: upper,
+			},
+			Vertex{
+				X: m.width - w,
+				Y: lower,
+			}
+	} else {
+		w := int(float64(m.width) * (ratio - 0.66) * 3)
+		upper, _ := m.band(2)
+		lower, _ := m.band(3)
+		return Vertex{
+				X: w,
+				Y: upper,
+			},
+			Vertex{
+				X: w,
+				Y: lower,
+			}
+	}
+}
+
+func (m *SlideModel) renderHoritontalLineColor(ratio float64, color1, color2, color3, color4 string) {
+	// まずはそれぞれの線の比率を計算する
+	upper, lower := m.calcHeadVertex(ratio)
+	upperDistance := m.calcDistance(upper)
+	lowerDistance := m.calcDistance(lower)
+	width1, width2, width3 := m.getHorizontalWidths(ratio)
+	m.renderColorWithDistance(ratio, upperDistance, lowerDistance, width1, width2, width3, color1, color2, color3, color4)
+}
+
+func (m *SlideModel) renderLoopBackColor(ratio float64, color1, color2, color3, color4 string) {
+	upper, lower := m.calcHeadVertex(ratio)
+	upperDistance := m.calcDistance(upper)
+	lowerDistance := m.calcDistance(lower)
+	m.renderColorWithDistance(ratio, upperDistance+3*m.width, lowerDistance+3*m.width, m.width, m.width, m.width, color1, color2, color3, color4)
+}
+
+func (m *SlideModel) renderColorWithDistance(ratio float64, upperDistance, lowerDistance, width1, width2, width3 int, color1, color2, color3, color4 string) {
+	colorAt := func(x, y, headDistance int) termenv.Color {
+		d := m.calcDistance(Vertex{
+			X: x, Y: y,
+		})
+		return termenv.TrueColor.Color(getColorFromDistance(headDistance-d, m.width, color1, color2, color3, color4))
+	}
+
+	for _, index := range []int{0, 5} {
+		start, end := m.band(index)
+		headDistance := upperDistance
+		if index >= 3 {
+			headDistance = lowerDistance
+		}
+		for y := start; y < end; y++ {
+			for x := 0; x < width1; x++ {
+				m.canvas.SetFG(x, y, colorAt(x, y, headDistance))
+			}
+		}
+	}
+
+	for _, index := range []int{1, 4} {
+		start, end := m.band(index)
+		headDistance := upperDistance
+		if index >= 3 {
+			headDistance = lowerDistance
+		}
+		for y := start; y < end; y++ {
+			for x := m.width - 1; x >= m.width-width2; x-- {
+				m.canvas.SetFG(x, y, colorAt(x, y, headDistance))
+			}
+		}
+	}
+
+	for _, index := range []int{2, 3} {
+		start, end := m.band(index)
+		headDistance := upperDistance
+		if index >= 3 {
+			headDistance = lowerDistance
+		}
+		for y := start; y < end; y++ {
+			for x := 0; x < width3; x++ {
+				m.canvas.SetFG(x, y, colorAt(x, y, headDistance))
+			}
+		}
+	}
+}
+
+// Draw は現在のフェーズをキャンバスに描画して返します。
+func (m *SlideModel) Draw() *Canvas {
+	ratio := m.ratio
+	if m.current().Hold && m.progress != nil {
+		// 読み込みより先には進めない
+		ratio = min(ratio, m.progress.Ratio)
+	}
+	m.current().Render(m, ratio)
+	return m.canvas
+}
+
+func (m *SlideModel) View() string {
+	return m.Draw().String()
+}
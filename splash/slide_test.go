@@ -0,0 +1,126 @@
+package splash
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/muesli/termenv"
+	"github.com/wolfmagnate/charm-demo/internal/golden"
+)
+
+// goldenRatios はフェーズごとに期待値と比べる進行度です。
+var goldenRatios = []float64{0, 0.25, 0.5, 0.75}
+
+func TestPhaseGolden(t *testing.T) {
+	for _, kind := range []AnimationType{Dark, Point, Light, Open, Progress, Horizontal, Loopback} {
+		for _, ratio := range goldenRatios {
+			name := fmt.Sprintf("%s_%.2f", kind, ratio)
+			t.Run(name, func(t *testing.T) {
+				scene := DefaultScene()
+				scene.Timeline = NewTimeline(kind)
+				m := NewSlideModel(scene)
+				m.Seek(0, ratio)
+				golden.Assert(t, name, serializeCanvas(m.Draw()))
+			})
+		}
+	}
+}
+
+// serializeCanvas はキャンバスを差分の読みやすいテキストにします。
+// 前半に文字だけを、後半に行ごとの色を「連続するマスの数*文字色/背景色」の並びで書きます。
+func serializeCanvas(c *Canvas) []byte {
+	b := strings.Builder{}
+	for y := 0; y < c.Height(); y++ {
+		for _, cell := range c.Row(y) {
+			b.WriteRune(cell.Rune)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("--- styles ---\n")
+	for y := 0; y < c.Height(); y++ {
+		fmt.Fprintf(&b, "%d:", y)
+		row := c.Row(y)
+		for x := 0; x < len(row); {
+			style := cellStyleKey(row[x])
+			end := x + 1
+			for end < len(row) && cellStyleKey(row[end]) == style {
+				end++
+			}
+			fmt.Fprintf(&b, " %d*%s", end-x, style)
+			x = end
+		}
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+func cellStyleKey(cell Cell) string {
+	key := colorHex(cell.FG) + "/" + colorHex(cell.BG)
+	if cell.Attrs != 0 {
+		key += fmt.Sprintf("/%d", cell.Attrs)
+	}
+	return key
+}
+
+// colorHex は色を rrggbb の形で返します。色がなければ none を返します。
+func colorHex(c termenv.Color) string {
+	if c == nil {
+		return "none"
+	}
+	if _, ok := c.(termenv.NoColor); ok {
+		return "none"
+	}
+	r, g, b := termenv.ConvertToRGB(c).RGB255()
+	return fmt.Sprintf("%02x%02x%02x", r, g, b)
+}
+
+func TestUpdateFollowsClock(t *testing.T) {
+	m := NewSlideModel(DefaultScene())
+	clock := NewFakeClock(time.Time{})
+	m.SetClock(clock)
+
+	first := m.Timeline[0].Duration
+	clock.Add(first / 2)
+	m.Update()
+	if m.Phase != 0 || m.Ratio != 0.5 {
+		t.Fatalf("after half of the first phase: got phase %d ratio %g, want phase 0 ratio 0.5", m.Phase, m.Ratio)
+	}
+
+	// 1周分進めると同じ位置に戻るので、残りの半分で次のフェーズの先頭に来る
+	clock.Add(m.Timeline.Length() + first/2)
+	m.Update()
+	if m.Phase != 1 || m.Ratio != 0 {
+		t.Fatalf("after one loop: got phase %d ratio %g, want phase 1 ratio 0", m.Phase, m.Ratio)
+	}
+}
+
+func TestAdvanceBackward(t *testing.T) {
+	m := NewSlideModel(DefaultScene())
+	m.Seek(1, 0)
+	m.Advance(-m.Timeline[0].Duration / 2)
+	if m.Phase != 0 || m.Ratio != 0.5 {
+		t.Fatalf("stepping back from phase 1: got phase %d ratio %g, want phase 0 ratio 0.5", m.Phase, m.Ratio)
+	}
+
+	// 先頭より前に戻ると最後のフェーズに回り込む
+	last := len(m.Timeline) - 1
+	m.Seek(0, 0)
+	m.Advance(-m.Timeline[last].Duration / 4)
+	if m.Phase != last || m.Ratio != 0.75 {
+		t.Fatalf("stepping back from the start: got phase %d ratio %g, want phase %d ratio 0.75", m.Phase, m.Ratio, last)
+	}
+}
+
+func TestNewSlideModelEmptyTimeline(t *testing.T) {
+	scene := DefaultScene()
+	scene.Timeline = nil
+	m := NewSlideModel(scene)
+	if len(m.Timeline) != len(DefaultTimeline()) {
+		t.Fatalf("got %d phases, want the default timeline", len(m.Timeline))
+	}
+	// 空のタイムラインでも進めて描ける
+	m.Advance(FrameInterval)
+	m.Draw()
+}
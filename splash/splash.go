@@ -0,0 +1,241 @@
+// Package splash は端末アプリケーションの起動時に再生するイントロのアニメーションです。
+//
+// アプリケーションの tea.Model を New で包むと、イントロを1周再生してからそのモデルに操作を引き継ぎます。
+//
+//	p := tea.NewProgram(splash.New(app), tea.WithAltScreen())
+//
+// 引き継ぐときにはアプリケーションのモデルに SplashDoneMsg が届きます。
+//
+// WaitForLoading や WithProgress を使うと、Progress のフェーズでロゴを塗る位置がアプリケーションの読み込みの進み具合に従い、
+// 読み込みが終わるまでそのフェーズで待ちます。Progress のような Hold のフェーズがないシーンでは、最後のフェーズの終わりで待ちます。
+// 進み具合は ProgressMsg で知らせます。
+package splash
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+)
+
+// FrameRate は1秒あたりに描画するフレーム数です。端末での再生と書き出しで同じ値を使います。
+// アニメーションの長さは経過時間で決まるので、この値を変えても再生にかかる時間は変わりません。
+const FrameRate = 25
+
+// FrameInterval はフレームを描画する間隔です。
+const FrameInterval = time.Second / FrameRate
+
+// SplashDoneMsg はイントロが終わって操作を引き継いだときに、引き継ぎ先のモデルに届くメッセージです。
+type SplashDoneMsg struct {
+	Skipped bool // Skipped はキー操作で途中で飛ばされたかどうかです。
+}
+
+// ProgressMsg はアプリケーションの読み込みの進み具合を知らせるメッセージです。
+// tea.Program の Send で送るか、WithProgress に渡したチャネルに送ります。
+type ProgressMsg struct {
+	Ratio  float64 // Ratio は読み込みの進み具合を 0 から 1 で表します。1 で読み込みが終わったものとみなします。
+	Status string  // Status はロゴの下に表示する、いま何をしているかの説明です。
+}
+
+// progressUpdate は WithProgress のチャネルから受け取った進み具合です。
+type progressUpdate struct {
+	ProgressMsg
+	closed bool
+}
+
+type tickMsg time.Time
+
+// Model はイントロを再生し、終わったら引き継ぎ先のモデルに操作を渡す tea.Model です。
+// イントロの間も、キー入力と描画以外のメッセージは引き継ぎ先に届けるので、裏で読み込みを進められます。
+type Model struct {
+	slide   *SlideModel
+	colors  *ColorMapper
+	clock   Clock
+	skip    key.Binding
+	next    tea.Model
+	started bool
+	waiting bool // waiting が真なら読み込みが終わるまで Progress のフェーズで待ちます。
+	updates <-chan ProgressMsg
+	stop    chan struct{} // stop は引き継いだときに閉じ、待っているコマンドを止めます。
+}
+
+// New は既定のシーンを再生し、終わったら next に操作を引き継ぐモデルを作ります。
+func New(next tea.Model) Model {
+	return Model{
+		slide:  NewSlideModel(DefaultScene()),
+		colors: NewColorMapper(termenv.EnvColorProfile(), false),
+		clock:  RealClock(),
+		skip: key.NewBinding(
+			key.WithKeys("enter", " ", "esc", "q"),
+			key.WithHelp("enter", "skip"),
+		),
+		next: next,
+		stop: make(chan struct{}),
+	}
+}
+
+// WithScene は scene を再生するモデルを返します。
+func (m Model) WithScene(scene *Scene) Model {
+	m.slide = NewSlideModel(scene)
+	return m
+}
+
+// WithClock は clock で時間を測るモデルを返します。
+func (m Model) WithClock(clock Clock) Model {
+	m.clock = clock
+	return m
+}
+
+// WithColors は colors で端末に合わせて色を変換するモデルを返します。
+func (m Model) WithColors(colors *ColorMapper) Model {
+	m.colors = colors
+	return m
+}
+
+// WithSkipKeys は skip に一致するキーでイントロを飛ばすモデルを返します。
+func (m Model) WithSkipKeys(skip key.Binding) Model {
+	m.skip = skip
+	return m
+}
+
+// WaitForLoading は、ProgressMsg で読み込みの終わりが知らされるまで Progress のフェーズで待つモデルを返します。
+// シーンに Hold のフェーズがなければ最後のフェーズの終わりで待つので、読み込みの前に引き継ぐことはありません。
+func (m Model) WaitForLoading() Model {
+	m.waiting = true
+	return m
+}
+
+// WithProgress は ch から届く進み具合に合わせて再生するモデルを返します。ch を閉じると読み込みが終わったものとみなします。
+// 引き継いだ後は ch から受け取らなくなるので、送る側が止まらないよう ch にはバッファを持たせてください。
+func (m Model) WithProgress(ch <-chan ProgressMsg) Model {
+	m.waiting = true
+	m.updates = ch
+	return m
+}
+
+// Slide は再生している SlideModel を返します。
+func (m Model) Slide() *SlideModel {
+	return m.slide
+}
+
+func (m Model) Init() tea.Cmd {
+	if m.waiting {
+		m.slide.SetProgress(0, "")
+	}
+	return tea.Batch(m.tick(), m.listen(), m.next.Init())
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		if key.Matches(msg, m.skip) {
+			return m.done(true)
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		// 引き継いだ後に大きさが分かるよう、引き継ぎ先にも届ける
+		m.slide.Resize(msg.Width, msg.Height)
+		return m.forward(msg)
+
+	case ProgressMsg:
+		m.slide.SetProgress(msg.Ratio, msg.Status)
+		return m, nil
+
+	case progressUpdate:
+		if msg.closed {
+			// 最後に知らされた状況を表示したまま読み込みを終える
+			status := ""
+			if m.slide.progress != nil {
+				status = m.slide.progress.Status
+			}
+			m.slide.SetProgress(1, status)
+			return m, nil
+		}
+		m.slide.SetProgress(msg.Ratio, msg.Status)
+		return m, m.listen()
+
+	case tickMsg:
+		if !m.started {
+			// 起動にかかった時間を飛ばさないよう、最初のティックから測る
+			m.started = true
+			m.slide.SetClock(m.clock)
+		}
+		m.slide.Update()
+		if m.slide.Loops() > 0 {
+			return m.done(false)
+		}
+		return m, m.tick()
+
+	default:
+		return m.forward(msg)
+	}
+}
+
+func (m Model) View() string {
+	return m.colors.Convert(m.slide.Draw()).String()
+}
+
+// forward は msg を引き継ぎ先のモデルに届けます。
+func (m Model) forward(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.next, cmd = m.next.Update(msg)
+	return m, cmd
+}
+
+// done はイントロを終え、引き継ぎ先のモデルに SplashDoneMsg を届けます。
+// 待っている listen と tick のコマンドは止め、引き継ぎ先にはイントロの内部のメッセージを届けません。
+func (m Model) done(skipped bool) (tea.Model, tea.Cmd) {
+	m.finish()
+	return m.next, func() tea.Msg {
+		return SplashDoneMsg{Skipped: skipped}
+	}
+}
+
+// listen は WithProgress のチャネルから次の進み具合を受け取るコマンドを返します。
+func (m Model) listen() tea.Cmd {
+	if m.updates == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case msg, ok := <-m.updates:
+			if m.finished() {
+				return nil
+			}
+			return progressUpdate{ProgressMsg: msg, closed: !ok}
+		case <-m.stop:
+			return nil
+		}
+	}
+}
+
+func (m Model) tick() tea.Cmd {
+	return m.clock.Tick(FrameInterval, func(t time.Time) tea.Msg {
+		if m.finished() {
+			return nil
+		}
+		return tickMsg(t)
+	})
+}
+
+// finish は引き継いだことを listen と tick に知らせます。
+func (m Model) finish() {
+	if m.stop != nil && !m.finished() {
+		close(m.stop)
+	}
+}
+
+// finished は引き継いだ後かどうかを返します。
+func (m Model) finished() bool {
+	select {
+	case <-m.stop:
+		return true
+	default:
+		return false
+	}
+}
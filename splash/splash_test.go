@@ -0,0 +1,154 @@
+package splash
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// appModel は引き継ぎ先のアプリケーションの代わりに、受け取ったメッセージを記録します。
+type appModel struct {
+	received []tea.Msg
+}
+
+func (a *appModel) Init() tea.Cmd { return nil }
+
+func (a *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	a.received = append(a.received, msg)
+	return a, nil
+}
+
+func (a *appModel) View() string { return "app" }
+
+func TestSplashHandsOverAfterOnePass(t *testing.T) {
+	app := &appModel{}
+	clock := NewFakeClock(time.Time{})
+	var model tea.Model = New(app).WithClock(clock)
+
+	cmd := model.(Model).tick()
+	ticks := 0
+	for {
+		var next tea.Cmd
+		model, next = model.Update(cmd())
+		cmd = next
+		if model == tea.Model(app) {
+			break
+		}
+		ticks++
+	}
+
+	length := DefaultTimeline().Length()
+	if want := int((length + FrameInterval - 1) / FrameInterval); ticks < want-1 || ticks > want+1 {
+		t.Errorf("splash ended after %d ticks, want about %d", ticks, want)
+	}
+	if msg := cmd(); msg != (SplashDoneMsg{Skipped: false}) {
+		t.Errorf("got %#v after the splash ended, want SplashDoneMsg", msg)
+	}
+}
+
+func TestSplashSkip(t *testing.T) {
+	app := &appModel{}
+	model, cmd := New(app).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model != tea.Model(app) {
+		t.Fatalf("skip did not hand over to the app model")
+	}
+	if msg := cmd(); msg != (SplashDoneMsg{Skipped: true}) {
+		t.Errorf("got %#v after skipping, want SplashDoneMsg{Skipped: true}", msg)
+	}
+}
+
+func TestSplashForwardsMessages(t *testing.T) {
+	type loadedMsg struct{}
+	app := &appModel{}
+	model, _ := New(app).Update(loadedMsg{})
+	if _, ok := model.(Model); !ok {
+		t.Fatalf("splash handed over before it finished")
+	}
+	if len(app.received) != 1 || app.received[0] != (loadedMsg{}) {
+		t.Errorf("app received %#v, want the forwarded message", app.received)
+	}
+}
+
+func TestSplashWaitsForLoading(t *testing.T) {
+	app := &appModel{}
+	clock := NewFakeClock(time.Time{})
+	m := New(app).WithClock(clock).WaitForLoading()
+	m.Init()
+	progress := m.Slide().PhaseIndex("Progress")
+
+	// タイムライン2周分進めても、読み込みが終わるまで Progress のフェーズで待つ
+	var model tea.Model = m
+	for i := 0; i < 2*int(m.Slide().Timeline.Length()/FrameInterval); i++ {
+		model, _ = model.Update(tickMsg(clock.Now()))
+		clock.Add(FrameInterval)
+	}
+	if _, ok := model.(Model); !ok {
+		t.Fatalf("splash handed over before loading finished")
+	}
+	if phase := model.(Model).Slide().Phase; phase != progress {
+		t.Fatalf("waiting in phase %d, want the Progress phase %d", phase, progress)
+	}
+
+	model, _ = model.Update(ProgressMsg{Ratio: 1, Status: "ready"})
+	for i := 0; i < int(m.Slide().Timeline.Length()/FrameInterval); i++ {
+		if model == tea.Model(app) {
+			return
+		}
+		model, _ = model.Update(tickMsg(clock.Now()))
+		clock.Add(FrameInterval)
+	}
+	t.Fatalf("splash did not hand over after loading finished")
+}
+
+func TestSplashStopsListeningAfterHandOver(t *testing.T) {
+	app := &appModel{}
+	ch := make(chan ProgressMsg, 1)
+	m := New(app).WithProgress(ch)
+	listen := m.listen()
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model != tea.Model(app) {
+		t.Fatalf("skip did not hand over to the app model")
+	}
+	// 引き継いだ後に届いた進み具合は引き継ぎ先に渡さない
+	ch <- ProgressMsg{Ratio: 0.5}
+	if msg := listen(); msg != nil {
+		model.Update(msg)
+	}
+	for _, msg := range app.received {
+		if _, ok := msg.(progressUpdate); ok {
+			t.Errorf("app received %#v after the splash ended", msg)
+		}
+	}
+}
+
+func TestSplashWaitsAtLastPhaseWithoutHold(t *testing.T) {
+	app := &appModel{}
+	clock := NewFakeClock(time.Time{})
+	scene := DefaultScene()
+	scene.Timeline = NewTimeline(Dark, Open)
+	m := New(app).WithScene(scene).WithClock(clock).WaitForLoading()
+	m.Init()
+
+	// Hold のフェーズがなくても、読み込みが終わるまで最後のフェーズで待つ
+	var model tea.Model = m
+	for i := 0; i < 2*int(scene.Timeline.Length()/FrameInterval); i++ {
+		model, _ = model.Update(tickMsg(clock.Now()))
+		clock.Add(FrameInterval)
+	}
+	if _, ok := model.(Model); !ok {
+		t.Fatalf("splash handed over before loading finished")
+	}
+	if phase := model.(Model).Slide().Phase; phase != len(scene.Timeline)-1 {
+		t.Fatalf("waiting in phase %d, want the last phase", phase)
+	}
+
+	model, _ = model.Update(ProgressMsg{Ratio: 1})
+	model, _ = model.Update(tickMsg(clock.Now()))
+	clock.Add(FrameInterval)
+	model, _ = model.Update(tickMsg(clock.Now()))
+	if model != tea.Model(app) {
+		t.Fatalf("splash did not hand over after loading finished")
+	}
+}
@@ -0,0 +1,711 @@
+package splash
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LoadSVG は SVG ファイルを読み、ImportSVG で線にします。
+func LoadSVG(path string, width, height int) ([][]Vertex, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening SVG file: %w", err)
+	}
+	defer file.Close()
+	return ImportSVG(file, width, height)
+}
+
+// ImportSVG は SVG の path, line, polyline, polygon 要素を読み、width × height のマス目に合わせた線にします。
+// viewBox (なければ 0 0 width height) の範囲を preserveAspectRatio に従ってマス目に収めます。
+// 既定の xMidYMid meet では画面で見たときの縦横比を保って中央に置き、none ならマス目全体に引き伸ばします。
+// 端末のマスは幅のおよそ CellAspect 倍の高さなので、縦横比を保つときは縦の倍率を横の 1/CellAspect にします。
+// viewBox も width と height もなければ1単位を1マスとします。
+// 要素や g 要素の transform も反映します。サブパスごとにひとつの線になり、線の向きはパスを書いた向きのままです。
+// ベジェ曲線は制御点ごと Curve として残します。円弧は円のまま写せるときは Curve に、楕円になるときは短い直線に分けます。
+func ImportSVG(r io.Reader, width, height int) ([][]Vertex, error) {
+	decoder := xml.NewDecoder(r)
+	var lines [][]Vertex
+	var stack []affine
+	// hidden は defs のように、中の要素がそのままは描かれない要素の深さです
+	hidden := 0
+	count := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SVG: %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if hidden > 0 || svgHiddenElements[t.Name.Local] {
+				hidden++
+				continue
+			}
+			attrs := svgAttrs(t)
+			parent := identity
+			if len(stack) == 0 {
+				if t.Name.Local != "svg" {
+					return nil, fmt.Errorf("error parsing SVG: root element is <%s>, want <svg>", t.Name.Local)
+				}
+				viewport, err := svgViewport(attrs, width, height)
+				if err != nil {
+					return nil, err
+				}
+				parent = viewport
+			} else {
+				parent = stack[len(stack)-1]
+			}
+			m, err := parseTransform(attrs["transform"])
+			if err != nil {
+				return nil, fmt.Errorf("<%s>: transform: %w", t.Name.Local, err)
+			}
+			m = parent.mul(m)
+			stack = append(stack, m)
+
+			b := &pathBuilder{m: m}
+			switch t.Name.Local {
+			case "path":
+				err = b.path(attrs["d"])
+			case "line":
+				err = b.line(attrs)
+			case "polyline":
+				err = b.polyline(attrs["points"], false)
+			case "polygon":
+				err = b.polyline(attrs["points"], true)
+			default:
+				continue
+			}
+			count++
+			if err != nil {
+				return nil, fmt.Errorf("element %d <%s>: %w", count, t.Name.Local, err)
+			}
+			lines = append(lines, b.finish()...)
+		case xml.EndElement:
+			if hidden > 0 {
+				hidden--
+				continue
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("error parsing SVG: no path, line, polyline or polygon elements found")
+	}
+	return lines, nil
+}
+
+// svgHiddenElements は中身をそのまま描かない要素です。
+var svgHiddenElements = map[string]bool{
+	"defs": true, "symbol": true, "clipPath": true, "mask": true, "pattern": true, "marker": true,
+}
+
+func svgAttrs(e xml.StartElement) map[string]string {
+	attrs := make(map[string]string, len(e.Attr))
+	for _, a := range e.Attr {
+		if a.Name.Space == "" {
+			attrs[a.Name.Local] = a.Value
+		}
+	}
+	return attrs
+}
+
+// CellAspect は端末のマスの高さと幅の比です。SVG の図形を縦に伸ばさずに取り込むのに使います。
+const CellAspect = 2
+
+// svgViewport は SVG の座標をマス目の座標に移す変換を返します。
+func svgViewport(attrs map[string]string, width, height int) (affine, error) {
+	var minX, minY, w, h float64
+	if viewBox, ok := attrs["viewBox"]; ok {
+		values, err := parseNumbers(viewBox)
+		if err != nil || len(values) != 4 || values[2] <= 0 || values[3] <= 0 {
+			return affine{}, fmt.Errorf("<svg>: viewBox: invalid value %q", viewBox)
+		}
+		minX, minY, w, h = values[0], values[1], values[2], values[3]
+	} else {
+		var okW, okH bool
+		w, okW = svgLength(attrs["width"])
+		h, okH = svgLength(attrs["height"])
+		if !okW || !okH {
+			return identity, nil
+		}
+	}
+
+	align, slice, err := parseAspectRatio(attrs["preserveAspectRatio"])
+	if err != nil {
+		return affine{}, fmt.Errorf("<svg>: preserveAspectRatio: %w", err)
+	}
+	sx, sy := float64(width)/w, float64(height)/h
+	var tx, ty float64
+	if align != "none" {
+		// マスの幅を単位にした画面上の大きさで倍率を決める
+		scale := math.Min(sx, sy*CellAspect)
+		if slice {
+			scale = math.Max(sx, sy*CellAspect)
+		}
+		sx, sy = scale, scale/CellAspect
+		// 余った幅と高さを align に従って左右と上下に振り分ける
+		position := func(a string, free float64) float64 {
+			switch a {
+			case "Mid":
+				return free / 2
+			case "Max":
+				return free
+			}
+			return 0
+		}
+		tx = position(align[1:4], float64(width)-w*sx)
+		ty = position(align[5:8], float64(height)-h*sy)
+	}
+	return affine{sx, 0, 0, sy, tx, ty}.mul(affine{1, 0, 0, 1, -minX, -minY}), nil
+}
+
+var aspectRatioPattern = regexp.MustCompile(`^(?:defer\s+)?(none|x(?:Min|Mid|Max)Y(?:Min|Mid|Max))(?:\s+(meet|slice))?$`)
+
+// parseAspectRatio は preserveAspectRatio 属性を読み、揃え方と、はみ出してでも埋めるかどうかを返します。
+// 省略されたときは xMidYMid meet です。
+func parseAspectRatio(s string) (string, bool, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "xMidYMid", false, nil
+	}
+	match := aspectRatioPattern.FindStringSubmatch(s)
+	if match == nil {
+		return "", false, fmt.Errorf("invalid value %q", s)
+	}
+	return match[1], match[2] == "slice", nil
+}
+
+// svgLength は "100" や "100px" のような長さを読みます。割合や0以下の長さは扱いません。
+func svgLength(s string) (float64, bool) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "px"))
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// affine は SVG の matrix(a, b, c, d, e, f) と同じ並びのアフィン変換です。
+type affine [6]float64
+
+var identity = affine{1, 0, 0, 1, 0, 0}
+
+// mul は n を適用してから m を適用する変換を返します。
+func (m affine) mul(n affine) affine {
+	return affine{
+		m[0]*n[0] + m[2]*n[1],
+		m[1]*n[0] + m[3]*n[1],
+		m[0]*n[2] + m[2]*n[3],
+		m[1]*n[2] + m[3]*n[3],
+		m[0]*n[4] + m[2]*n[5] + m[4],
+		m[1]*n[4] + m[3]*n[5] + m[5],
+	}
+}
+
+func (m affine) apply(x, y float64) (float64, float64) {
+	return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+}
+
+func (m affine) det() float64 {
+	return m[0]*m[3] - m[1]*m[2]
+}
+
+// similarity は m が回転と一様な拡大縮小 (と裏返し) だけの変換かどうかを返します。円は m で写しても円のままです。
+func (m affine) similarity() bool {
+	const eps = 1e-9
+	scale := math.Max(math.Abs(m[0])+math.Abs(m[1]), math.Abs(m[2])+math.Abs(m[3]))
+	near := func(a, b float64) bool { return math.Abs(a-b) <= eps*scale }
+	return (near(m[0], m[3]) && near(m[1], -m[2])) || (near(m[0], -m[3]) && near(m[1], m[2]))
+}
+
+var transformPattern = regexp.MustCompile(`\s*,?\s*([A-Za-z]+)\s*\(([^)]*)\)`)
+
+// parseTransform は transform 属性を読み、左から順に掛け合わせた変換を返します。
+func parseTransform(s string) (affine, error) {
+	m := identity
+	rest := strings.TrimSpace(s)
+	for rest != "" {
+		match := transformPattern.FindStringSubmatchIndex(rest)
+		if match == nil || match[0] != 0 {
+			return affine{}, fmt.Errorf("invalid value %q", s)
+		}
+		name := rest[match[2]:match[3]]
+		args, err := parseNumbers(rest[match[4]:match[5]])
+		if err != nil {
+			return affine{}, fmt.Errorf("%s: %w", name, err)
+		}
+		t, err := transformFunction(name, args)
+		if err != nil {
+			return affine{}, err
+		}
+		m = m.mul(t)
+		rest = strings.TrimSpace(rest[match[1]:])
+	}
+	return m, nil
+}
+
+func transformFunction(name string, args []float64) (affine, error) {
+	arg := func(i int, def float64) float64 {
+		if i < len(args) {
+			return args[i]
+		}
+		return def
+	}
+	want := func(counts ...int) error {
+		for _, c := range counts {
+			if len(args) == c {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: unexpected number of arguments %d", name, len(args))
+	}
+	switch name {
+	case "matrix":
+		if err := want(6); err != nil {
+			return affine{}, err
+		}
+		return affine{args[0], args[1], args[2], args[3], args[4], args[5]}, nil
+	case "translate":
+		if err := want(1, 2); err != nil {
+			return affine{}, err
+		}
+		return affine{1, 0, 0, 1, args[0], arg(1, 0)}, nil
+	case "scale":
+		if err := want(1, 2); err != nil {
+			return affine{}, err
+		}
+		return affine{args[0], 0, 0, arg(1, args[0]), 0, 0}, nil
+	case "rotate":
+		if err := want(1, 3); err != nil {
+			return affine{}, err
+		}
+		a := args[0] * math.Pi / 180
+		cx, cy := arg(1, 0), arg(2, 0)
+		rotate := affine{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0}
+		return affine{1, 0, 0, 1, cx, cy}.mul(rotate).mul(affine{1, 0, 0, 1, -cx, -cy}), nil
+	case "skewX":
+		if err := want(1); err != nil {
+			return affine{}, err
+		}
+		return affine{1, 0, math.Tan(args[0] * math.Pi / 180), 1, 0, 0}, nil
+	case "skewY":
+		if err := want(1); err != nil {
+			return affine{}, err
+		}
+		return affine{1, math.Tan(args[0] * math.Pi / 180), 0, 1, 0, 0}, nil
+	}
+	return affine{}, fmt.Errorf("unknown transform function %q", name)
+}
+
+// parseNumbers はカンマか空白で区切った数の並びを読みます。
+func parseNumbers(s string) ([]float64, error) {
+	sc := &pathScanner{s: s}
+	var values []float64
+	for sc.skip(); sc.i < len(sc.s); sc.skip() {
+		v, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// pathScanner はパスデータの数と命令を先頭から順に読みます。
+// "10-5" や "1.5.5" のように区切りを省いた書き方も SVG の規則どおりに読みます。
+type pathScanner struct {
+	s string
+	i int
+}
+
+// skip は空白とカンマを読み飛ばします。
+func (sc *pathScanner) skip() {
+	for sc.i < len(sc.s) && strings.IndexByte(" \t\r\n,", sc.s[sc.i]) >= 0 {
+		sc.i++
+	}
+}
+
+// atNumber は次に数が続くかどうかを返します。
+func (sc *pathScanner) atNumber() bool {
+	sc.skip()
+	return sc.i < len(sc.s) && strings.IndexByte("+-.0123456789", sc.s[sc.i]) >= 0
+}
+
+func (sc *pathScanner) number() (float64, error) {
+	sc.skip()
+	start := sc.i
+	digits := func() int {
+		n := 0
+		for sc.i < len(sc.s) && sc.s[sc.i] >= '0' && sc.s[sc.i] <= '9' {
+			sc.i++
+			n++
+		}
+		return n
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+		sc.i++
+	}
+	n := digits()
+	if sc.i < len(sc.s) && sc.s[sc.i] == '.' {
+		sc.i++
+		n += digits()
+	}
+	if n == 0 {
+		return 0, sc.errorf("expected a number")
+	}
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		sc.i++
+		if sc.i < len(sc.s) && (sc.s[sc.i] == '+' || sc.s[sc.i] == '-') {
+			sc.i++
+		}
+		if digits() == 0 {
+			return 0, sc.errorf("expected an exponent")
+		}
+	}
+	return strconv.ParseFloat(sc.s[start:sc.i], 64)
+}
+
+// flag は円弧の大小や向きを表す0か1を読みます。
+func (sc *pathScanner) flag() (bool, error) {
+	sc.skip()
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '0' || sc.s[sc.i] == '1') {
+		sc.i++
+		return sc.s[sc.i-1] == '1', nil
+	}
+	return false, sc.errorf("expected a flag (0 or 1)")
+}
+
+// numbers は n 個の数を読みます。
+func (sc *pathScanner) numbers(n int) ([]float64, error) {
+	values := make([]float64, n)
+	for i := range values {
+		v, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (sc *pathScanner) errorf(format string, args ...any) error {
+	return fmt.Errorf("at offset %d: %s", sc.i, fmt.Sprintf(format, args...))
+}
+
+// pathBuilder は SVG の座標で書かれたパスを変換 m でマス目に移しながら線を組み立てます。
+type pathBuilder struct {
+	m     affine
+	lines [][]Vertex
+	cur   []Vertex
+
+	x, y           float64 // 現在の点
+	startX, startY float64 // サブパスの始点
+	ctrlX, ctrlY   float64 // S や T で折り返す直前の制御点
+	last           byte    // 直前の命令 (大文字)
+}
+
+func (b *pathBuilder) cell(x, y float64) Vertex {
+	tx, ty := b.m.apply(x, y)
+	return Vertex{X: int(math.Round(tx)), Y: int(math.Round(ty))}
+}
+
+func (b *pathBuilder) flush() {
+	if len(b.cur) >= 2 {
+		b.lines = append(b.lines, b.cur)
+	}
+	b.cur = nil
+}
+
+func (b *pathBuilder) finish() [][]Vertex {
+	b.flush()
+	return b.lines
+}
+
+func (b *pathBuilder) moveTo(x, y float64) {
+	b.flush()
+	b.cur = []Vertex{b.cell(x, y)}
+	b.x, b.y = x, y
+	b.startX, b.startY = x, y
+}
+
+// add は線の最後に頂点を加えます。直線で同じマスが続くときは加えません。
+func (b *pathBuilder) add(v Vertex) {
+	if last := b.cur[len(b.cur)-1]; v.Curve == nil && last.X == v.X && last.Y == v.Y {
+		return
+	}
+	b.cur = append(b.cur, v)
+}
+
+func (b *pathBuilder) lineTo(x, y float64) {
+	b.add(b.cell(x, y))
+	b.x, b.y = x, y
+}
+
+func (b *pathBuilder) curveTo(x, y float64, controls ...[2]float64) {
+	v := b.cell(x, y)
+	curve := &Curve{}
+	// 制御点はマスに丸めず、端点だけを丸める
+	for _, c := range controls {
+		tx, ty := b.m.apply(c[0], c[1])
+		curve.Controls = append(curve.Controls, ControlPoint{X: tx, Y: ty})
+	}
+	v.Curve = curve
+	b.add(v)
+	last := controls[len(controls)-1]
+	b.ctrlX, b.ctrlY = last[0], last[1]
+	b.x, b.y = x, y
+}
+
+// arcTo は SVG の円弧を加えます。円のまま写せるなら Curve に、そうでなければ短い直線に分けます。
+func (b *pathBuilder) arcTo(rx, ry, rotation float64, largeArc, sweep bool, x, y float64) {
+	x1, y1 := b.x, b.y
+	b.x, b.y = x, y
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if x1 == x && y1 == y {
+		return
+	}
+	if rx == 0 || ry == 0 {
+		b.add(b.cell(x, y))
+		return
+	}
+	if math.Abs(rx-ry) <= 1e-9*rx && b.m.similarity() {
+		v := b.cell(x, y)
+		// 裏返す変換では回る向きも逆になる
+		v.Curve = &Curve{
+			Radius:    rx * math.Sqrt(math.Abs(b.m.det())),
+			Clockwise: sweep != (b.m.det() < 0),
+			LargeArc:  largeArc,
+		}
+		b.add(v)
+		return
+	}
+
+	// SVG 仕様の付録にある、端点から中心を求める方法
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+	hx, hy := (x1-x)/2, (y1-y)/2
+	px := cos*hx + sin*hy
+	py := -sin*hx + cos*hy
+	if lambda := px*px/(rx*rx) + py*py/(ry*ry); lambda > 1 {
+		rx *= math.Sqrt(lambda)
+		ry *= math.Sqrt(lambda)
+	}
+	num := rx*rx*ry*ry - rx*rx*py*py - ry*ry*px*px
+	den := rx*rx*py*py + ry*ry*px*px
+	coef := math.Sqrt(math.Max(0, num/den))
+	if largeArc == sweep {
+		coef = -coef
+	}
+	qx, qy := coef*rx*py/ry, -coef*ry*px/rx
+	cx := cos*qx - sin*qy + (x1+x)/2
+	cy := sin*qx + cos*qy + (y1+y)/2
+
+	start := math.Atan2((py-qy)/ry, (px-qx)/rx)
+	delta := math.Atan2((-py-qy)/ry, (-px-qx)/rx) - start
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	n := max(int(math.Ceil(math.Abs(delta)/(2*math.Pi)*64)), 4)
+	for k := 1; k <= n; k++ {
+		a := start + delta*float64(k)/float64(n)
+		ex, ey := rx*math.Cos(a), ry*math.Sin(a)
+		b.add(b.cell(cos*ex-sin*ey+cx, sin*ex+cos*ey+cy))
+	}
+}
+
+// closePath は始点に戻ってサブパスを閉じます。続けて描くと、同じ始点から次のサブパスになります。
+func (b *pathBuilder) closePath() {
+	b.lineTo(b.startX, b.startY)
+	b.flush()
+	b.cur = []Vertex{b.cell(b.startX, b.startY)}
+}
+
+// path は path 要素の d 属性を読みます。
+func (b *pathBuilder) path(d string) error {
+	sc := &pathScanner{s: d}
+	var command byte
+	for {
+		sc.skip()
+		if sc.i >= len(sc.s) {
+			return nil
+		}
+		if c := sc.s[sc.i]; strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+			command = c
+			sc.i++
+		} else if command == 0 || !sc.atNumber() {
+			return sc.errorf("unexpected %q", c)
+		}
+		if b.cur == nil && command != 'M' && command != 'm' {
+			return sc.errorf("path data must start with a moveto command")
+		}
+		if err := b.segment(sc, command); err != nil {
+			return err
+		}
+		// moveto の後に続く座標は lineto として読む
+		switch command {
+		case 'M':
+			command = 'L'
+		case 'm':
+			command = 'l'
+		case 'Z', 'z':
+			if sc.atNumber() {
+				return sc.errorf("unexpected number after closepath")
+			}
+		}
+	}
+}
+
+func (b *pathBuilder) segment(sc *pathScanner, command byte) error {
+	relative := command >= 'a'
+	upper := command &^ 0x20
+	offset := func(x, y float64) (float64, float64) {
+		if relative {
+			return b.x + x, b.y + y
+		}
+		return x, y
+	}
+	// S と T は直前が同じ種類の曲線なら、その制御点を現在の点で折り返す
+	reflected := func(kinds string) [2]float64 {
+		if strings.IndexByte(kinds, b.last) >= 0 {
+			return [2]float64{2*b.x - b.ctrlX, 2*b.y - b.ctrlY}
+		}
+		return [2]float64{b.x, b.y}
+	}
+	defer func() { b.last = upper }()
+
+	switch upper {
+	case 'M', 'L':
+		v, err := sc.numbers(2)
+		if err != nil {
+			return err
+		}
+		x, y := offset(v[0], v[1])
+		if upper == 'M' {
+			b.moveTo(x, y)
+		} else {
+			b.lineTo(x, y)
+		}
+	case 'H':
+		v, err := sc.numbers(1)
+		if err != nil {
+			return err
+		}
+		x, _ := offset(v[0], 0)
+		b.lineTo(x, b.y)
+	case 'V':
+		v, err := sc.numbers(1)
+		if err != nil {
+			return err
+		}
+		_, y := offset(0, v[0])
+		b.lineTo(b.x, y)
+	case 'C':
+		v, err := sc.numbers(6)
+		if err != nil {
+			return err
+		}
+		x1, y1 := offset(v[0], v[1])
+		x2, y2 := offset(v[2], v[3])
+		x, y := offset(v[4], v[5])
+		b.curveTo(x, y, [2]float64{x1, y1}, [2]float64{x2, y2})
+	case 'S':
+		v, err := sc.numbers(4)
+		if err != nil {
+			return err
+		}
+		x2, y2 := offset(v[0], v[1])
+		x, y := offset(v[2], v[3])
+		b.curveTo(x, y, reflected("CS"), [2]float64{x2, y2})
+	case 'Q':
+		v, err := sc.numbers(4)
+		if err != nil {
+			return err
+		}
+		x1, y1 := offset(v[0], v[1])
+		x, y := offset(v[2], v[3])
+		b.curveTo(x, y, [2]float64{x1, y1})
+	case 'T':
+		v, err := sc.numbers(2)
+		if err != nil {
+			return err
+		}
+		x, y := offset(v[0], v[1])
+		b.curveTo(x, y, reflected("QT"))
+	case 'A':
+		v, err := sc.numbers(3)
+		if err != nil {
+			return err
+		}
+		largeArc, err := sc.flag()
+		if err != nil {
+			return err
+		}
+		sweep, err := sc.flag()
+		if err != nil {
+			return err
+		}
+		p, err := sc.numbers(2)
+		if err != nil {
+			return err
+		}
+		x, y := offset(p[0], p[1])
+		b.arcTo(v[0], v[1], v[2], largeArc, sweep, x, y)
+	case 'Z':
+		b.closePath()
+	}
+	return nil
+}
+
+// line は line 要素の端点を読みます。
+func (b *pathBuilder) line(attrs map[string]string) error {
+	var v [4]float64
+	for i, name := range []string{"x1", "y1", "x2", "y2"} {
+		s, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", name, s)
+		}
+		v[i] = f
+	}
+	b.moveTo(v[0], v[1])
+	b.lineTo(v[2], v[3])
+	return nil
+}
+
+// polyline は polyline と polygon 要素の points 属性を読みます。closed なら始点に戻ります。
+func (b *pathBuilder) polyline(points string, closed bool) error {
+	values, err := parseNumbers(points)
+	if err != nil {
+		return fmt.Errorf("points: %w", err)
+	}
+	if len(values)%2 != 0 {
+		return fmt.Errorf("points: odd number of coordinates %d", len(values))
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		if i == 0 {
+			b.moveTo(values[0], values[1])
+		} else {
+			b.lineTo(values[i], values[i+1])
+		}
+	}
+	if closed && len(values) > 0 {
+		b.closePath()
+	}
+	return nil
+}
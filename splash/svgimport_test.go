@@ -0,0 +1,111 @@
+package splash
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportSVG(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 200 100">
+  <defs><path d="M0 0 L200 100"/></defs>
+  <g transform="translate(20 0)">
+    <path d="M10-0L10 20h20q20 0 20 20"/>
+  </g>
+  <polyline points="0,100 100,80"/>
+</svg>`
+	// マスは縦長なので、画面で 2:1 の 100 × 25 マスにちょうど収まる
+	lines, err := ImportSVG(strings.NewReader(svg), 100, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]Vertex{
+		{{X: 15, Y: 0}, {X: 15, Y: 5}, {X: 25, Y: 5}, {X: 35, Y: 10, Curve: &Curve{Controls: []ControlPoint{{X: 35, Y: 5}}}}},
+		{{X: 0, Y: 25}, {X: 50, Y: 20}},
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("got %+v, want %+v", lines, want)
+	}
+}
+
+func TestImportSVGArc(t *testing.T) {
+	// マス目での縦横の倍率が同じなら円弧は Curve のまま、違えば直線に分ける
+	path := `<path d="M0 0 A10 10 0 0 1 10 10"/>`
+	lines, err := ImportSVG(strings.NewReader(`<svg viewBox="0 0 20 20" preserveAspectRatio="none">`+path+`</svg>`), 40, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Vertex{{X: 0, Y: 0}, {X: 20, Y: 20, Curve: &Curve{Radius: 20, Clockwise: true}}}
+	if !reflect.DeepEqual(lines[0], want) {
+		t.Errorf("got %+v, want %+v", lines[0], want)
+	}
+
+	// 縦横比を保つと、縦長のマスの上では楕円になる
+	lines, err = ImportSVG(strings.NewReader(`<svg viewBox="0 0 20 20">`+path+`</svg>`), 40, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range lines[0] {
+		if v.Curve != nil {
+			t.Fatalf("elliptical arc was kept as a curve: %+v", v)
+		}
+	}
+	if last := lines[0][len(lines[0])-1]; last.X != 20 || last.Y != 10 {
+		t.Errorf("arc ends at %+v, want (20, 10)", last)
+	}
+}
+
+func TestImportSVGAspectRatio(t *testing.T) {
+	line := `<line x1="0" y1="0" x2="20" y2="10"/>`
+	tests := []struct {
+		aspect string
+		want   []Vertex
+	}{
+		// 既定の xMidYMid meet は横に4倍、縦長のマスに合わせて縦に2倍して中央に置く
+		{"", []Vertex{{X: 10, Y: 0}, {X: 90, Y: 20}}},
+		{"xMinYMax meet", []Vertex{{X: 0, Y: 0}, {X: 80, Y: 20}}},
+		{"xMidYMin slice", []Vertex{{X: 0, Y: 0}, {X: 100, Y: 25}}},
+		{"none", []Vertex{{X: 0, Y: 0}, {X: 100, Y: 20}}},
+	}
+	for _, tt := range tests {
+		svg := `<svg viewBox="0 0 20 10" preserveAspectRatio="` + tt.aspect + `">` + line + `</svg>`
+		lines, err := ImportSVG(strings.NewReader(svg), 100, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(lines[0], tt.want) {
+			t.Errorf("preserveAspectRatio=%q: got %+v, want %+v", tt.aspect, lines[0], tt.want)
+		}
+	}
+}
+
+func TestImportSVGKeepsControlPointsUnrounded(t *testing.T) {
+	svg := `<svg viewBox="0 0 10 10" preserveAspectRatio="none"><path d="M0 0 Q 1.3 4.6 10 10"/></svg>`
+	lines, err := ImportSVG(strings.NewReader(svg), 10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lines[0][1].Curve.Controls, []ControlPoint{{X: 1.3, Y: 4.6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestImportSVGErrors(t *testing.T) {
+	tests := []struct {
+		svg  string
+		want string
+	}{
+		{`<html/>`, "root element is <html>"},
+		{`<svg><path d="L1 1"/></svg>`, "must start with a moveto"},
+		{`<svg><path d="M1 1 L2"/></svg>`, "expected a number"},
+		{`<svg><g transform="spin(3)"><line/></g></svg>`, "unknown transform function"},
+		{`<svg><rect/></svg>`, "no path, line, polyline or polygon"},
+		{`<svg viewBox="0 0 1 1" preserveAspectRatio="xMidYMid crop"><line/></svg>`, "preserveAspectRatio: invalid value"},
+	}
+	for _, tt := range tests {
+		_, err := ImportSVG(strings.NewReader(tt.svg), DesignWidth, DesignHeight)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want an error containing %q", tt.svg, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package splash
+
+import "math"
+
+// SymmetryKind は片側の線からもう片側の線を作るときの写し方です。
+type SymmetryKind int
+
+const (
+	// SymmetryHorizontal は縦の軸 x = X で左右を反転します。
+	SymmetryHorizontal SymmetryKind = iota
+	// SymmetryVertical は横の軸 y = Y で上下を反転します。
+	SymmetryVertical
+	// SymmetryRotational は点 (X, Y) を中心に180度回転します。
+	SymmetryRotational
+)
+
+var symmetryKindNames = map[SymmetryKind]string{
+	SymmetryHorizontal: "horizontal",
+	SymmetryVertical:   "vertical",
+	SymmetryRotational: "rotational",
+}
+
+func (k SymmetryKind) String() string {
+	if name, ok := symmetryKindNames[k]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseSymmetryKind は名前から対称性の種類を引きます。
+func ParseSymmetryKind(name string) (SymmetryKind, bool) {
+	for k, n := range symmetryKindNames {
+		if n == name {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// 既定の線は中央の2つのコアの間を通る x = 84 の軸で左右対称です。
+const (
+	DefaultSymmetryX = 84
+	DefaultSymmetryY = DesignHeight / 2
+)
+
+// Symmetry はシーンの線の対称性です。LeftLines を写して RightLines を作ります。
+// X と Y は軸や中心の位置で、SymmetryHorizontal は X だけ、SymmetryVertical は Y だけを使います。
+type Symmetry struct {
+	Kind SymmetryKind
+	X, Y float64
+}
+
+// DefaultSymmetry は既定の線に使う左右対称を返します。
+func DefaultSymmetry() *Symmetry {
+	return &Symmetry{Kind: SymmetryHorizontal, X: DefaultSymmetryX, Y: DefaultSymmetryY}
+}
+
+// Apply は lines を写した線を返します。線の向きはそのままなので、色は元の線と同じく始点から付きます。
+// 曲線の制御点も同じように写し、裏返しになる写し方では円弧の回る向きを逆にします。
+func (s Symmetry) Apply(lines [][]Vertex) [][]Vertex {
+	result := make([][]Vertex, 0, len(lines))
+	for _, line := range lines {
+		mirrored := make([]Vertex, 0, len(line))
+		for _, v := range line {
+			mirrored = append(mirrored, s.applyVertex(v))
+		}
+		result = append(result, mirrored)
+	}
+	return result
+}
+
+func (s Symmetry) applyVertex(v Vertex) Vertex {
+	x, y := s.applyPoint(float64(v.X), float64(v.Y))
+	v.X, v.Y = int(math.Round(x)), int(math.Round(y))
+	if v.Curve != nil {
+		curve := *v.Curve
+		if len(v.Curve.Controls) > 0 {
+			curve.Controls = make([]ControlPoint, 0, len(v.Curve.Controls))
+			for _, c := range v.Curve.Controls {
+				x, y := s.applyPoint(c.X, c.Y)
+				curve.Controls = append(curve.Controls, ControlPoint{X: x, Y: y})
+			}
+		}
+		if s.Kind != SymmetryRotational {
+			curve.Clockwise = !curve.Clockwise
+		}
+		v.Curve = &curve
+	}
+	return v
+}
+
+func (s Symmetry) applyPoint(x, y float64) (float64, float64) {
+	switch s.Kind {
+	case SymmetryHorizontal:
+		x = 2*s.X - x
+	case SymmetryVertical:
+		y = 2*s.Y - y
+	case SymmetryRotational:
+		x, y = 2*s.X-x, 2*s.Y-y
+	}
+	return x, y
+}
@@ -0,0 +1,55 @@
+package splash
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSymmetryApply(t *testing.T) {
+	line := [][]Vertex{{{X: 82, Y: 15}, {X: 70, Y: 5, Curve: &Curve{Radius: 4, Clockwise: true}}}}
+	tests := []struct {
+		symmetry Symmetry
+		want     []Vertex
+	}{
+		{Symmetry{Kind: SymmetryHorizontal, X: 84}, []Vertex{{X: 86, Y: 15}, {X: 98, Y: 5, Curve: &Curve{Radius: 4}}}},
+		{Symmetry{Kind: SymmetryVertical, Y: 17}, []Vertex{{X: 82, Y: 19}, {X: 70, Y: 29, Curve: &Curve{Radius: 4}}}},
+		{Symmetry{Kind: SymmetryRotational, X: 84, Y: 17}, []Vertex{{X: 86, Y: 19}, {X: 98, Y: 29, Curve: &Curve{Radius: 4, Clockwise: true}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.symmetry.Kind.String(), func(t *testing.T) {
+			got := tt.symmetry.Apply(line)
+			if !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("got %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestSymmetryRoundTrip(t *testing.T) {
+	lines := [][]Vertex{{
+		{X: 82, Y: 15},
+		{X: 70, Y: 5, Curve: &Curve{Radius: 4}},
+		{X: 60, Y: 0, Curve: &Curve{Controls: []ControlPoint{{X: 65, Y: 1}, {X: 62, Y: 3}}}},
+	}}
+	for _, symmetry := range []Symmetry{
+		{Kind: SymmetryHorizontal, X: 84},
+		{Kind: SymmetryVertical, Y: 17},
+		{Kind: SymmetryRotational, X: 84, Y: 17},
+	} {
+		// 同じ写し方を2回適用すると元に戻る
+		if back := symmetry.Apply(symmetry.Apply(lines)); !reflect.DeepEqual(back, lines) {
+			t.Errorf("%s: got %+v, want %+v", symmetry.Kind, back, lines)
+		}
+	}
+}
+
+func TestDefaultSceneIsSymmetric(t *testing.T) {
+	scene := DefaultScene()
+	if len(scene.LeftLines) == 0 {
+		t.Fatal("default scene has no lines")
+	}
+	// 右側の線をもう一度写すと左側の線に戻る
+	if back := DefaultSymmetry().Apply(scene.RightLines); !reflect.DeepEqual(back, scene.LeftLines) {
+		t.Errorf("mirroring the right lines again gives %+v, want %+v", back, scene.LeftLines)
+	}
+}
@@ -0,0 +1,159 @@
+package splash
+
+import "time"
+
+// Phase はタイムライン上の一区間を表す構造体です。
+// 名前・長さ・イージング・描画関数をひとまとめの値として持つので、
+// Update や View を書き換えずに並べ替えや追加・削除ができます。
+type Phase struct {
+	Name     string                             // Name はフェーズの名前です。
+	Duration time.Duration                      // Duration はフェーズの長さです。
+	Easing   func(float64) float64              // Easing は進行度に適用するイージング関数です。
+	Render   func(m *SlideModel, ratio float64) // Render はイージング適用後の進行度で画面を描画します。
+	Hold     bool                               // Hold が真のフェーズは、SetProgress で読み込みを待っている間は終わりで止まります。
+}
+
+// Timeline はフェーズを再生順に並べたものです。最後のフェーズの次は先頭に戻ります。
+type Timeline []Phase
+
+// AnimationType は組み込みフェーズの種類を定義する型です。
+type AnimationType int
+
+// AnimationType の許容される値を定義します。
+const (
+	Dark AnimationType = iota
+	Point
+	Light
+	Open
+	Progress
+	Horizontal
+	Loopback
+)
+
+var animationTypeNames = map[AnimationType]string{
+	Dark:       "Dark",
+	Point:      "Point",
+	Light:      "Light",
+	Open:       "Open",
+	Progress:   "Progress",
+	Horizontal: "Horizontal",
+	Loopback:   "Loopback",
+}
+
+// String は AnimationType の名前を返します。
+func (t AnimationType) String() string {
+	if name, ok := animationTypeNames[t]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// ParseAnimationType は名前から組み込みフェーズの種類を引きます。
+func ParseAnimationType(name string) (AnimationType, bool) {
+	for t, n := range animationTypeNames {
+		if n == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// builtinPhases は組み込みフェーズの既定値です。
+var builtinPhases = map[AnimationType]Phase{
+	Dark:       {Duration: 704 * time.Millisecond, Easing: Linear, Render: (*SlideModel).renderDark},
+	Point:      {Duration: 660 * time.Millisecond, Easing: Linear, Render: (*SlideModel).renderPoint},
+	Light:      {Duration: 660 * time.Millisecond, Easing: Linear, Render: (*SlideModel).renderLight},
+	Open:       {Duration: 880 * time.Millisecond, Easing: Ease3, Render: (*SlideModel).renderOpen},
+	Progress:   {Duration: 1100 * time.Millisecond, Easing: Ease1, Render: (*SlideModel).renderProgress, Hold: true},
+	Horizontal: {Duration: 2200 * time.Millisecond, Easing: Ease2, Render: (*SlideModel).renderHorizontal},
+	Loopback:   {Duration: 1232 * time.Millisecond, Easing: Linear, Render: (*SlideModel).renderLoopback},
+}
+
+// BuiltinPhase は組み込みフェーズを既定の長さとイージングで返します。
+func BuiltinPhase(t AnimationType) Phase {
+	p := builtinPhases[t]
+	p.Name = t.String()
+	return p
+}
+
+// NewTimeline は組み込みフェーズを指定した順に並べたタイムラインを返します。
+func NewTimeline(types ...AnimationType) Timeline {
+	timeline := make(Timeline, 0, len(types))
+	for _, t := range types {
+		timeline = append(timeline, BuiltinPhase(t))
+	}
+	return timeline
+}
+
+// DefaultTimeline は従来のイントロと同じ順序のタイムラインを返します。
+func DefaultTimeline() Timeline {
+	return NewTimeline(Dark, Point, Open, Progress, Horizontal, Loopback)
+}
+
+func (m *SlideModel) renderDark(ratio float64) {
+	m.renderLines(0)
+	m.renderCenter(0)
+	m.renderLineColor(-1)
+}
+
+func (m *SlideModel) renderPoint(ratio float64) {
+	m.renderLines(0)
+	m.renderCenter(0)
+	m.renderPointColor(ratio)
+	m.renderCenterColor(0)
+}
+
+func (m *SlideModel) renderLight(ratio float64) {
+	m.renderLines(0)
+	m.renderCenter(0)
+	m.renderLineColor(ratio)
+}
+
+func (m *SlideModel) renderOpen(ratio float64) {
+	m.clearAll()
+	m.renderLogo()
+	m.renderLogoBackgroundColor()
+	m.renderLines(ratio)
+	m.renderCenter(ratio)
+	m.renderLineColorWithOffset(5*ratio, ratio)
+	m.renderCenterColor(ratio)
+}
+
+func (m *SlideModel) renderProgress(ratio float64) {
+	m.clearAll()
+	m.renderLogo()
+	m.renderLogoBackgroundColor()
+	m.renderLogoColor(ratio, m.palette.Logo[0], m.palette.Logo[1], 2*ratio)
+	m.renderStatus()
+}
+
+func (m *SlideModel) renderHorizontal(ratio float64) {
+	m.clearAll()
+	m.renderLogo()
+	m.renderLogoBackgroundColor()
+	m.renderLogoColor(1, m.palette.Logo[0], m.palette.Logo[1], 2*ratio)
+	// 幅6の線をしたから引いていく
+	m.renderHorizontalHeader(ratio)
+	m.renderHoritontalLine(ratio)
+	m.renderHoritontalLineColor(ratio, m.palette.Sweep[0], m.palette.Sweep[1], m.palette.Sweep[2], m.palette.Sweep[3])
+}
+
+// renderLoopback は直前のフェーズに頼らず引き終わった線を描き直してから色を流します。
+func (m *SlideModel) renderLoopback(ratio float64) {
+	m.clearAll()
+	m.renderLogo()
+	m.renderLogoBackgroundColor()
+	m.renderLogoColor(1, m.palette.Logo[0], m.palette.Logo[1], 2)
+	m.renderHoritontalLine(1)
+	// 最後の色まで流し切るために少し先まで進める
+	m.renderLoopBackColor(1.1*ratio, m.palette.Sweep[0], m.palette.Sweep[1], m.palette.Sweep[2], m.palette.Sweep[3])
+}
+
+// Length はタイムラインを1周再生するのにかかる時間を返します。
+func (t Timeline) Length() time.Duration {
+	var length time.Duration
+	for _, p := range t {
+		length += p.Duration
+	}
+	return length
+}
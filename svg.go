@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/wolfmagnate/charm-demo/splash"
+)
+
+// writeSVG はフレームを CSS のキーフレームアニメーションで切り替える1枚の SVG として書き出します。
+// 各フレームには前のフレームから変わったマスだけを描き、表示を始めた時刻からループの終わりまで重ねて表示します。
+// 表示を始める時刻は Frame.Time をそのまま使うので、端末で再生したときと同じ間隔で切り替わります。
+func writeSVG(w io.Writer, frames []Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to export")
+	}
+	first := frames[0].Canvas
+	total := frames[len(frames)-1].Time + splash.FrameInterval
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" shape-rendering="crispEdges">`+"\n",
+		first.Width()*cellPixelWidth, first.Height()*cellPixelHeight,
+		first.Width()*cellPixelWidth, first.Height()*cellPixelHeight)
+	fmt.Fprintf(b, "<style>g{visibility:hidden;animation:%.3fs step-end infinite}text{font-family:monospace;font-size:%dpx;white-space:pre}</style>\n",
+		total.Seconds(), cellPixelHeight-2)
+
+	var prev *splash.Canvas
+	for i, frame := range frames {
+		area := frame.Canvas.Bounds()
+		if prev != nil {
+			area = changedArea(prev, frame.Canvas)
+			if area.Width == 0 || area.Height == 0 {
+				continue
+			}
+		}
+		if prev == nil {
+			// 最初のフレームはループの頭から常に表示しておく
+			b.WriteString(`<g style="visibility:visible">` + "\n")
+		} else {
+			name := fmt.Sprintf("f%d", i)
+			fmt.Fprintf(b, "<style>@keyframes %s{0%%{visibility:hidden}%s{visibility:visible}100%%{visibility:visible}}</style>\n",
+				name, keyframePercent(frame.Time, total))
+			fmt.Fprintf(b, `<g style="animation-name:%s">`+"\n", name)
+		}
+		for y := area.Y; y < area.Y+area.Height; y++ {
+			var prevRow []splash.Cell
+			if prev != nil {
+				prevRow = prev.Row(y)
+			}
+			writeSVGRow(b, frame.Canvas.Row(y), prevRow, y)
+		}
+		b.WriteString("</g>\n")
+		prev = frame.Canvas
+	}
+
+	b.WriteString("</svg>\n")
+	if err := b.Flush(); err != nil {
+		return fmt.Errorf("error writing SVG: %w", err)
+	}
+	return nil
+}
+
+// keyframePercent はループ全体のうち t が占める位置をキーフレームの割合で表します。
+func keyframePercent(t, total time.Duration) string {
+	return fmt.Sprintf("%.4g%%", 100*t.Seconds()/total.Seconds())
+}
+
+// writeSVGRow は y 行目のうち prev から変わったマスを描きます。prev が nil なら行全体を描きます。
+// 背景と文字はそれぞれ同じ色の続くマスをひとつの要素にまとめます。
+func writeSVGRow(b *bufio.Writer, row, prev []splash.Cell, y int) {
+	changed := func(x int) bool {
+		return prev == nil || row[x] != prev[x]
+	}
+	top := y * cellPixelHeight
+
+	// 背景
+	for x := 0; x < len(row); {
+		if !changed(x) {
+			x++
+			continue
+		}
+		bg := cellHex(row[x].BG, defaultBackground)
+		end := x + 1
+		for end < len(row) && changed(end) && cellHex(row[end].BG, defaultBackground) == bg {
+			end++
+		}
+		fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`, x*cellPixelWidth, top, (end-x)*cellPixelWidth, cellPixelHeight, bg)
+		x = end
+	}
+
+	// 文字
+	for x := 0; x < len(row); {
+		if !changed(x) || row[x].Rune == ' ' {
+			x++
+			continue
+		}
+		fg := cellHex(row[x].FG, defaultForeground)
+		left := x * cellPixelWidth
+		if shape, ok := svgShape(row[x].Rune, left, top); ok {
+			end := x + 1
+			if row[x].Rune == '█' {
+				for end < len(row) && changed(end) && row[end].Rune == '█' && cellHex(row[end].FG, defaultForeground) == fg {
+					end++
+				}
+				shape = fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d"`, left, top, (end-x)*cellPixelWidth, cellPixelHeight)
+			}
+			fmt.Fprintf(b, `%s fill="%s"/>`, shape, fg)
+			x = end
+			continue
+		}
+		end := x + 1
+		for end < len(row) && changed(end) && !isSVGShape(row[end].Rune) && cellHex(row[end].FG, defaultForeground) == fg {
+			end++
+		}
+		text := strings.Builder{}
+		for _, cell := range row[x:end] {
+			text.WriteRune(cell.Rune)
+		}
+		fmt.Fprintf(b, `<text x="%d" y="%d" textLength="%d" lengthAdjust="spacingAndGlyphs" fill="%s">%s</text>`,
+			left, top+cellPixelHeight-4, (end-x)*cellPixelWidth, fg, html.EscapeString(text.String()))
+		x = end
+	}
+	b.WriteString("\n")
+}
+
+// isSVGShape は文字を図形として描くかどうかを返します。
+func isSVGShape(r rune) bool {
+	_, ok := svgShape(r, 0, 0)
+	return ok || r == ' '
+}
+
+// svgShape はブロック要素や三角形を、フォントに頼らずマスにぴったり合う図形の要素にします。
+// 返す文字列は fill 属性と閉じタグを付け足して使います。
+func svgShape(r rune, x, y int) (string, bool) {
+	w, h := cellPixelWidth, cellPixelHeight
+	rect := func(x, y, w, h int) string {
+		return fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d"`, x, y, w, h)
+	}
+	polygon := func(points ...int) string {
+		s := make([]string, 0, len(points)/2)
+		for i := 0; i+1 < len(points); i += 2 {
+			s = append(s, fmt.Sprintf("%d,%d", points[i], points[i+1]))
+		}
+		return fmt.Sprintf(`<polygon points="%s"`, strings.Join(s, " "))
+	}
+	switch r {
+	case '█':
+		return rect(x, y, w, h), true
+	case '▀':
+		return rect(x, y, w, h/2), true
+	case '▄':
+		return rect(x, y+h/2, w, h/2), true
+	case '▌':
+		return rect(x, y, w/2, h), true
+	case '▐':
+		return rect(x+w/2, y, w/2, h), true
+	case '_':
+		return rect(x, y+h-2, w, 1), true
+	case '◢':
+		return polygon(x+w, y, x+w, y+h, x, y+h), true
+	case '◣':
+		return polygon(x, y, x+w, y+h, x, y+h), true
+	case '◤':
+		return polygon(x, y, x+w, y, x, y+h), true
+	case '◥':
+		return polygon(x, y, x+w, y, x+w, y+h), true
+	}
+	return "", false
+}